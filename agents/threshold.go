@@ -0,0 +1,32 @@
+package agents
+
+import "github.com/Sparhawk96/bank-ais/game"
+
+/**
+ * Creates an AI Agent that banks once the round points reach a threshold
+ *
+ * @param name AI Agent's name
+ * @param points Round points to bank at
+ *
+ * @return The created AI Agent
+ */
+func NewThreshold(name string, points uint) game.Player {
+	return Threshold{name, points}
+}
+
+type Threshold struct {
+	name   string
+	points uint
+}
+
+func (t Threshold) Name() string {
+	return t.name
+}
+
+func (t Threshold) Bank(g *game.Game) bool {
+	return t.points <= g.GetData(t).RoundPoints
+}
+
+func (t Threshold) AiAgent() bool {
+	return true
+}
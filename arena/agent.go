@@ -0,0 +1,201 @@
+package arena
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/Sparhawk96/bank-ais/game"
+)
+
+/**
+ * AI Agent playing from a remote connection.
+ *
+ * Every roll it's sent a snapshot and must reply with a decision before the
+ * deadline, otherwise it holds and the game records a fault.
+ */
+type remoteAgent struct {
+	name     string
+	conn     net.Conn
+	deadline time.Duration
+
+	writeMu   sync.Mutex
+	encoder   *json.Encoder
+	decisions chan Message // Closed once the connection is lost, only holds replies to the current seq
+
+	seqMu  sync.Mutex // Guards seq, so replies to earlier snapshots never fill the decisions buffer
+	seq    int
+	gameID string // Game the agent is currently playing, guarded by seqMu as a late TryBank may still read it
+}
+
+/**
+ * Wraps an authenticated connection, reading the agent's decisions in the background
+ *
+ * @param name Agent's name
+ * @param conn Agent's connection
+ * @param reader Reader of the connection used during authentication
+ * @param deadline Time the agent has to reply to a snapshot
+ *
+ * @return The remote agent
+ */
+func newRemoteAgent(name string, conn net.Conn, reader *bufio.Reader, deadline time.Duration) *remoteAgent {
+	agent := &remoteAgent{
+		name:      name,
+		conn:      conn,
+		deadline:  deadline,
+		encoder:   json.NewEncoder(conn),
+		decisions: make(chan Message, 1),
+	}
+
+	go func() {
+		defer close(agent.decisions)
+		decoder := json.NewDecoder(reader)
+		for {
+			var msg Message
+			if err := decoder.Decode(&msg); err != nil {
+				return
+			}
+			if msg.Type != DECISION {
+				continue
+			}
+
+			agent.seqMu.Lock()
+			if msg.Seq == agent.seq {
+				select {
+				case agent.decisions <- msg:
+				default: // Second reply to the same snapshot, drop it
+				}
+			} // Otherwise a late reply to an earlier snapshot, drop it
+			agent.seqMu.Unlock()
+		}
+	}()
+
+	return agent
+}
+
+func (a *remoteAgent) Name() string {
+	return a.name
+}
+
+func (a *remoteAgent) AiAgent() bool {
+	return true
+}
+
+func (a *remoteAgent) Bank(g *game.Game) bool {
+	bank, _ := a.TryBank(g)
+	return bank
+}
+
+func (a *remoteAgent) TryBank(g *game.Game) (bool, error) {
+	seq, gameID := a.nextSeq()
+	snapshot := g.GetData(a)
+	self := playerData(g.State(), a.name)
+
+	err := a.send(Message{
+		Type:       SNAPSHOT,
+		Game:       gameID,
+		Seq:        seq,
+		Snapshot:   &snapshot,
+		Self:       &self,
+		DeadlineMs: a.deadline.Milliseconds(),
+	})
+	if err != nil {
+		return false, fmt.Errorf("connection lost: %w", err)
+	}
+
+	timeout := time.NewTimer(a.deadline)
+	defer timeout.Stop()
+	select {
+	case msg, open := <-a.decisions:
+		if !open {
+			return false, errors.New("connection lost")
+		}
+
+		switch msg.Action {
+		case BANK:
+			return true, nil
+		case HOLD:
+			return false, nil
+		}
+		return false, fmt.Errorf("unknown action: '%s'", msg.Action)
+	case <-timeout.C:
+		return false, game.ErrAgentTimeout
+	}
+}
+
+/**
+ * Seats the agent in a game, its snapshots are then sent for that game
+ *
+ * @param id Game ID
+ */
+func (a *remoteAgent) join(id string) {
+	a.seqMu.Lock()
+	defer a.seqMu.Unlock()
+	a.gameID = id
+}
+
+/**
+ * Moves on to the next snapshot, dropping any unread reply to the last one
+ *
+ * @return Seq of the next snapshot and the game it's for
+ */
+func (a *remoteAgent) nextSeq() (int, string) {
+	a.seqMu.Lock()
+	defer a.seqMu.Unlock()
+
+	a.seq++
+	select {
+	case <-a.decisions: // Reply that came after its deadline
+	default:
+	}
+	return a.seq, a.gameID
+}
+
+/**
+ * Sends a message to the agent
+ *
+ * @param msg Message to send
+ *
+ * @return An error if the connection failed
+ */
+func (a *remoteAgent) send(msg Message) error {
+	a.writeMu.Lock()
+	defer a.writeMu.Unlock()
+	a.conn.SetWriteDeadline(time.Now().Add(a.deadline))
+	return a.encoder.Encode(msg)
+}
+
+/**
+ * Checks if the agent's connection was lost
+ *
+ * @return True if the agent disconnected
+ */
+func (a *remoteAgent) disconnected() bool {
+	select {
+	case _, open := <-a.decisions:
+		return !open
+	default:
+		return false
+	}
+}
+
+/**
+ * Finds a player in a snapshot
+ *
+ * @param snapshot Snapshot of every player
+ * @param name Player to find
+ *
+ * @return The player's data
+ */
+func playerData(snapshot game.BankDataSnapshot, name string) game.PlayerDataSnapshot {
+	for _, player := range snapshot.Players {
+		if player.Name == name {
+			return player
+		}
+	}
+	return game.PlayerDataSnapshot{Name: name}
+}
@@ -0,0 +1,350 @@
+package arena
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/Sparhawk96/bank-ais/game"
+)
+
+// Defaults used for unset Config fields
+const (
+	DEFAULT_PLAYERS_PER_GAME = 2
+	DEFAULT_DEADLINE         = time.Second
+)
+
+// Time an agent has to send its hello after connecting
+const HELLO_TIMEOUT = 10 * time.Second
+
+type Config struct {
+	PlayersPerGame  int           // Agents matched into each game
+	Deadline        time.Duration // Time an agent has to reply to a snapshot
+	LeaderboardPath string        // JSON file the leaderboard is kept in, empty to not save it
+	MaxFaults       int           // Faults that disqualify an agent from a game, 0 to never disqualify
+	Logger          *slog.Logger  // Traces every game, nil to not trace
+	Observer        game.Observer // Observes every game, such as a metrics collector, nil for none
+}
+
+/**
+ * Hosts games of Bank between remote AI Agents over TCP.
+ *
+ * Agents connect and send a hello with their name and token. The first token
+ * used with a name claims it. Waiting agents are matched into games in the
+ * order they connected and are queued again once their game is over.
+ * See protocol.go for the messages.
+ */
+type Arena struct {
+	config      Config
+	leaderboard *leaderboard
+
+	mu        sync.Mutex
+	listener  net.Listener
+	connected map[string]*remoteAgent
+	waiting   []*remoteAgent
+	nextGame  int
+	closed    bool
+	games     sync.WaitGroup
+}
+
+/**
+ * Creates an arena, loading its leaderboard
+ *
+ * @param config Arena configuration
+ *
+ * @return The arena, or an error if the leaderboard couldn't be loaded
+ */
+func New(config Config) (*Arena, error) {
+	if config.PlayersPerGame < 2 {
+		config.PlayersPerGame = DEFAULT_PLAYERS_PER_GAME
+	}
+	if config.Deadline <= 0 {
+		config.Deadline = DEFAULT_DEADLINE
+	}
+
+	lb, err := loadLeaderboard(config.LeaderboardPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Arena{
+		config:      config,
+		leaderboard: lb,
+		connected:   make(map[string]*remoteAgent),
+	}, nil
+}
+
+/**
+ * Accepts agents until the listener is closed
+ *
+ * @param listener Listener agents connect to
+ *
+ * @return nil once closed, otherwise the error that stopped the arena
+ */
+func (a *Arena) Serve(listener net.Listener) error {
+	a.mu.Lock()
+	a.listener = listener
+	a.mu.Unlock()
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			a.mu.Lock()
+			closed := a.closed
+			a.mu.Unlock()
+			if closed {
+				return nil
+			}
+			return err
+		}
+		go a.handle(conn)
+	}
+}
+
+/**
+ * Stops accepting agents, disconnects every agent and waits for games to finish
+ *
+ * @return An error if the listener failed to close
+ */
+func (a *Arena) Close() error {
+	a.mu.Lock()
+	a.closed = true
+	var err error
+	if a.listener != nil {
+		err = a.listener.Close()
+	}
+	for _, agent := range a.connected {
+		agent.conn.Close()
+	}
+	a.mu.Unlock()
+
+	a.games.Wait()
+	return err
+}
+
+/**
+ * Gets the leaderboard, sorted by wins then points
+ *
+ * @return Copy of the leaderboard entries
+ */
+func (a *Arena) Leaderboard() []LeaderboardEntry {
+	return a.leaderboard.sorted()
+}
+
+/**
+ * Gets the leaderboard as a table
+ *
+ * @return The leaderboard table
+ */
+func (a *Arena) LeaderboardString() string {
+	return leaderboardTable(a.Leaderboard()).String()
+}
+
+/**
+ * Authenticates a new connection and queues its agent
+ *
+ * @param conn Agent's connection
+ */
+func (a *Arena) handle(conn net.Conn) {
+	reader := bufio.NewReader(conn)
+
+	conn.SetReadDeadline(time.Now().Add(HELLO_TIMEOUT))
+	name, err := a.authenticate(reader)
+	conn.SetReadDeadline(time.Time{})
+	if err != nil {
+		json.NewEncoder(conn).Encode(Message{Type: ERROR, Error: err.Error()})
+		conn.Close()
+		return
+	}
+
+	agent := newRemoteAgent(name, conn, reader, a.config.Deadline)
+
+	a.mu.Lock()
+	if _, has := a.connected[name]; has || a.closed {
+		a.mu.Unlock()
+		agent.send(Message{Type: ERROR, Error: "agent is already connected"})
+		conn.Close()
+		return
+	}
+	a.connected[name] = agent
+	a.mu.Unlock()
+
+	if agent.send(Message{Type: WELCOME, Name: name}) != nil {
+		a.disconnect(agent)
+		return
+	}
+	a.enqueue(agent)
+}
+
+/**
+ * Reads and checks the agent's hello
+ *
+ * @param reader Reader of the agent's connection
+ *
+ * @return The agent's name, or an error if it failed to authenticate
+ */
+func (a *Arena) authenticate(reader *bufio.Reader) (string, error) {
+	line, err := reader.ReadBytes('\n')
+	if err != nil && !(errors.Is(err, io.EOF) && len(line) > 0) {
+		return "", errors.New("expected a hello")
+	}
+
+	var hello Message
+	if err := json.Unmarshal(line, &hello); err != nil || hello.Type != HELLO {
+		return "", errors.New("expected a hello")
+	} else if hello.Name == "" || hello.Token == "" {
+		return "", errors.New("name and token are required")
+	}
+
+	if err := a.leaderboard.authenticate(hello.Name, hello.Token); err != nil {
+		return "", err
+	}
+	return hello.Name, nil
+}
+
+/**
+ * Queues an agent, starting a game once enough agents are waiting
+ *
+ * @param agent Agent ready for a game
+ */
+func (a *Arena) enqueue(agent *remoteAgent) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.closed {
+		return
+	}
+
+	a.waiting = append(a.waiting, agent)
+	if len(a.waiting) < a.config.PlayersPerGame {
+		return
+	}
+
+	players := a.waiting[:a.config.PlayersPerGame]
+	a.waiting = append([]*remoteAgent(nil), a.waiting[a.config.PlayersPerGame:]...)
+	a.nextGame++
+
+	a.games.Add(1)
+	go func(id string) {
+		defer a.games.Done()
+		a.play(id, players)
+	}(fmt.Sprint(a.nextGame))
+}
+
+/**
+ * Plays a game between agents, recording it on the leaderboard and requeueing
+ * the agents that are still connected
+ *
+ * @param id Game ID
+ * @param players Agents playing
+ */
+func (a *Arena) play(id string, players []*remoteAgent) {
+	g, err := a.newGame(id, players)
+	if err != nil {
+		a.logger().Error("game not started", "game", id, "error", err)
+		for _, agent := range players {
+			a.enqueue(agent)
+		}
+		return
+	}
+
+	names := make([]string, 0, len(players))
+	for _, agent := range players {
+		names = append(names, agent.name)
+	}
+	for _, agent := range players {
+		agent.send(Message{Type: START, Game: id, Players: names})
+	}
+
+	for !g.Over() {
+		if _, err := g.Roll(); err != nil {
+			break
+		}
+	}
+
+	standings := g.State().Players
+	winners := g.Winners()
+	if err := a.leaderboard.record(standings, winners); err != nil {
+		a.logger().Error("leaderboard not saved", "game", id, "error", err)
+	}
+
+	for _, agent := range players {
+		end := Message{Type: END, Game: id, Standings: standings, Winners: winners, Faults: g.Faults()}
+		if agent.send(end) != nil || agent.disconnected() {
+			a.disconnect(agent)
+			continue
+		}
+		a.enqueue(agent)
+	}
+}
+
+/**
+ * Sets up and begins a game between agents
+ *
+ * @param id Game ID
+ * @param players Agents playing
+ *
+ * @return The started game, or an error if it couldn't be set up
+ */
+func (a *Arena) newGame(id string, players []*remoteAgent) (*game.Game, error) {
+	g := game.NewGame()
+	g.SetSeed(time.Now().UnixNano())
+	if err := g.SetMaxFaults(a.config.MaxFaults); err != nil {
+		return nil, err
+	}
+	if a.config.Logger != nil {
+		g.SetLogger(a.config.Logger.With("game", id))
+	}
+	if a.config.Observer != nil {
+		g.AddObserver(a.config.Observer)
+	}
+
+	// Agents time themselves out, this only guards against the connection stalling
+	if err := g.SetAgentTimeout(2 * a.config.Deadline); err != nil {
+		return nil, err
+	}
+
+	for _, agent := range players {
+		agent.join(id)
+		if err := g.AddPlayer(agent); err != nil {
+			return nil, err
+		}
+	}
+	if err := g.Begin(); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+/**
+ * Gets the logger for the arena's errors
+ *
+ * @return The configured logger, or the default logger if none was configured
+ */
+func (a *Arena) logger() *slog.Logger {
+	if a.config.Logger != nil {
+		return a.config.Logger
+	}
+	return slog.Default()
+}
+
+/**
+ * Closes an agent's connection, freeing its name
+ *
+ * @param agent Agent to disconnect
+ */
+func (a *Arena) disconnect(agent *remoteAgent) {
+	agent.conn.Close()
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.connected[agent.name] == agent {
+		delete(a.connected, agent.name)
+	}
+}
@@ -0,0 +1,338 @@
+package arena
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Sparhawk96/bank-ais/game"
+)
+
+/**
+ * Starts an arena on a loopback port
+ */
+func startArena(t *testing.T, config Config) (*Arena, string) {
+	t.Helper()
+
+	a, err := New(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go a.Serve(listener)
+	t.Cleanup(func() { a.Close() })
+
+	return a, listener.Addr().String()
+}
+
+/**
+ * Loopback bot that banks once the round points reach its threshold
+ */
+type bot struct {
+	t       *testing.T
+	conn    net.Conn
+	decoder *json.Decoder
+	encoder *json.Encoder
+}
+
+func dial(t *testing.T, addr, name, token string) (*bot, Message) {
+	t.Helper()
+
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	conn.SetDeadline(time.Now().Add(30 * time.Second))
+
+	b := &bot{t, conn, json.NewDecoder(bufio.NewReader(conn)), json.NewEncoder(conn)}
+	b.encoder.Encode(Message{Type: HELLO, Name: name, Token: token})
+	return b, b.read()
+}
+
+func (b *bot) read() Message {
+	b.t.Helper()
+	var msg Message
+	if err := b.decoder.Decode(&msg); err != nil {
+		b.t.Fatal(err)
+	}
+	return msg
+}
+
+/**
+ * Plays a game, replying to every snapshot
+ *
+ * @param decide Decides on a snapshot, nil to never reply
+ *
+ * @return The end of game message
+ */
+func (b *bot) playGame(decide func(Message) string) Message {
+	b.t.Helper()
+	snapshots := 0
+	for {
+		msg := b.read()
+		switch msg.Type {
+		case SNAPSHOT:
+			snapshots++
+			if decide != nil {
+				b.encoder.Encode(Message{Type: DECISION, Seq: msg.Seq, Action: decide(msg)})
+			}
+		case END:
+			if snapshots == 0 {
+				b.t.Error("game ended without any snapshots")
+			}
+			return msg
+		}
+	}
+}
+
+func bankAt(points uint) func(Message) string {
+	return func(msg Message) string {
+		if msg.Snapshot.RoundPoints >= points {
+			return BANK
+		}
+		return HOLD
+	}
+}
+
+func TestArenaPlaysGames(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "leaderboard.json")
+	a, addr := startArena(t, Config{Deadline: time.Second, LeaderboardPath: path})
+
+	alice, welcome := dial(t, addr, "alice", "a-token")
+	if welcome.Type != WELCOME {
+		t.Fatalf("expected a welcome, got %+v", welcome)
+	}
+	bob, _ := dial(t, addr, "bob", "b-token")
+
+	if start := alice.read(); start.Type != START || len(start.Players) != 2 {
+		t.Fatalf("expected a start with 2 players, got %+v", start)
+	}
+	bob.read()
+
+	done := make(chan Message)
+	go func() { done <- bob.playGame(bankAt(200)) }()
+	end := alice.playGame(bankAt(100))
+	<-done
+
+	if len(end.Standings) != 2 || len(end.Winners) == 0 {
+		t.Fatalf("expected standings and winners, got %+v", end)
+	}
+
+	// Agents are matched again after their game
+	if start := alice.read(); start.Type != START {
+		t.Fatalf("expected another game, got %+v", start)
+	}
+
+	entries := a.Leaderboard()
+	if len(entries) != 2 || entries[0].Games != 1 || entries[0].Wins != 1 {
+		t.Fatalf("unexpected leaderboard: %+v", entries)
+	}
+
+	// The leaderboard was saved
+	lb, err := loadLeaderboard(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved := lb.sorted(); saved[0] != entries[0] {
+		t.Errorf("saved leaderboard %+v, expected %+v", saved, entries)
+	}
+}
+
+func TestArenaRejectsInvalidTokens(t *testing.T) {
+	_, addr := startArena(t, Config{})
+
+	dial(t, addr, "alice", "a-token")
+	if _, msg := dial(t, addr, "alice", "a-token"); msg.Type != ERROR {
+		t.Errorf("expected the second connection to be rejected, got %+v", msg)
+	}
+	if _, msg := dial(t, addr, "alice", "wrong"); msg.Type != ERROR {
+		t.Errorf("expected the wrong token to be rejected, got %+v", msg)
+	}
+	if _, msg := dial(t, addr, "", "token"); msg.Type != ERROR {
+		t.Errorf("expected a missing name to be rejected, got %+v", msg)
+	}
+}
+
+func TestArenaSilentAgentsHold(t *testing.T) {
+	_, addr := startArena(t, Config{Deadline: 5 * time.Millisecond})
+
+	alice, _ := dial(t, addr, "alice", "a-token")
+	silent, _ := dial(t, addr, "silent", "s-token")
+	alice.read()
+	silent.read()
+
+	done := make(chan Message)
+	go func() { done <- silent.playGame(nil) }()
+	end := alice.playGame(bankAt(50))
+	<-done
+
+	for _, player := range end.Standings {
+		if player.Name == "silent" && player.Points != 0 {
+			t.Errorf("silent agent should never bank, has %d points", player.Points)
+		}
+	}
+}
+
+func TestArenaReportsFaults(t *testing.T) {
+	_, addr := startArena(t, Config{Deadline: 50 * time.Millisecond, MaxFaults: 2})
+
+	alice, _ := dial(t, addr, "alice", "a-token")
+	garbled, _ := dial(t, addr, "garbled", "g-token")
+	alice.read()
+	garbled.read()
+
+	done := make(chan Message)
+	go func() { done <- garbled.playGame(func(Message) string { return "maybe" }) }()
+	alice.playGame(bankAt(50))
+	end := <-done
+
+	if len(end.Faults) != 2 || end.Faults[0].Kind != game.FAULT_INVALID_RESPONSE || !end.Faults[1].Disqualified {
+		t.Errorf("expected 2 invalid responses then a disqualification, got %+v", end.Faults)
+	}
+}
+
+func TestLateRepliesAreDropped(t *testing.T) {
+	conn, client := net.Pipe()
+	defer conn.Close()
+	defer client.Close()
+
+	agent := newRemoteAgent("bot", conn, bufio.NewReader(conn), 200*time.Millisecond)
+	g := game.NewGame()
+	g.AddPlayer(agent)
+
+	decoder := json.NewDecoder(client)
+	encoder := json.NewEncoder(client)
+	read := func() Message {
+		var msg Message
+		if err := decoder.Decode(&msg); err != nil {
+			t.Fatal(err)
+		}
+		return msg
+	}
+
+	type result struct {
+		bank bool
+		err  error
+	}
+	tryBank := func() <-chan result {
+		done := make(chan result, 1)
+		go func() {
+			bank, err := agent.TryBank(g)
+			done <- result{bank, err}
+		}()
+		return done
+	}
+
+	// The first snapshot is answered after its deadline
+	done := tryBank()
+	first := read()
+	if res := <-done; res.err != game.ErrAgentTimeout {
+		t.Fatalf("expected a timeout, got %+v", res)
+	}
+	encoder.Encode(Message{Type: DECISION, Seq: first.Seq, Action: BANK})
+
+	// Late replies, before and after the next snapshot, don't take the place of its reply
+	done = tryBank()
+	second := read()
+	encoder.Encode(Message{Type: DECISION, Seq: first.Seq, Action: BANK})
+	encoder.Encode(Message{Type: DECISION, Seq: second.Seq, Action: HOLD})
+	if res := <-done; res.bank || res.err != nil {
+		t.Errorf("expected the reply to the second snapshot, got %+v", res)
+	}
+}
+
+/**
+ * Writer that is safe to write from the arena's games while a test reads it
+ */
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestArenaLogsUnsavedLeaderboard(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "arena")
+	os.Mkdir(dir, 0755)
+
+	var logs syncBuffer
+	config := Config{
+		Deadline:        time.Second,
+		LeaderboardPath: filepath.Join(dir, "leaderboard.json"),
+		Logger:          slog.New(slog.NewTextHandler(&logs, nil)),
+	}
+	_, addr := startArena(t, config)
+
+	alice, _ := dial(t, addr, "alice", "a-token")
+	bob, _ := dial(t, addr, "bob", "b-token")
+	alice.read()
+	bob.read()
+
+	// The leaderboard can't be saved once its directory is gone
+	if err := os.RemoveAll(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	done := make(chan Message)
+	go func() { done <- bob.playGame(bankAt(200)) }()
+	alice.playGame(bankAt(100))
+	<-done
+
+	if !strings.Contains(logs.String(), "leaderboard not saved") {
+		t.Errorf("expected the save error to be logged:\n%s", logs.String())
+	}
+}
+
+func TestArenaRequeuesAgentsOfUnstartedGames(t *testing.T) {
+	var logs syncBuffer
+	a, err := New(Config{
+		PlayersPerGame: 3,
+		Logger:         slog.New(slog.NewTextHandler(&logs, nil)),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Two agents with the same name can't both be added to the game
+	players := make([]*remoteAgent, 2)
+	for i := range players {
+		conn, other := net.Pipe()
+		t.Cleanup(func() { conn.Close(); other.Close() })
+		players[i] = newRemoteAgent("alice", conn, bufio.NewReader(conn), time.Second)
+	}
+
+	a.play("1", players)
+
+	if !strings.Contains(logs.String(), "game not started") {
+		t.Errorf("expected the setup error to be logged:\n%s", logs.String())
+	}
+	if entries := a.Leaderboard(); len(entries) != 0 {
+		t.Errorf("expected no games recorded, got %+v", entries)
+	}
+	if len(a.waiting) != len(players) {
+		t.Errorf("expected %d agents requeued, got %d", len(players), len(a.waiting))
+	}
+}
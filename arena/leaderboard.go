@@ -0,0 +1,204 @@
+package arena
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/Sparhawk96/bank-ais/game"
+	"github.com/Sparhawk96/bank-ais/i18n"
+	"github.com/Sparhawk96/bank-ais/table"
+)
+
+type LeaderboardEntry struct {
+	Name      string `json:"name"`
+	TokenHash string `json:"tokenHash"` // Claims the name for the first token used with it
+	Games     int    `json:"games"`
+	Wins      int    `json:"wins"`
+	Points    uint   `json:"points"`
+}
+
+/**
+ * Results of every agent across all games, saved to a JSON file after each game
+ */
+type leaderboard struct {
+	mu      sync.Mutex
+	path    string // Empty to keep it in memory only
+	entries map[string]*LeaderboardEntry
+}
+
+/**
+ * Loads the leaderboard from a file
+ *
+ * @param path JSON file of the leaderboard, empty to not save it
+ *
+ * @return The leaderboard, or an error if the file exists and couldn't be read
+ */
+func loadLeaderboard(path string) (*leaderboard, error) {
+	lb := &leaderboard{path: path, entries: make(map[string]*LeaderboardEntry)}
+	if path == "" {
+		return lb, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return lb, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*LeaderboardEntry, 0)
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		lb.entries[entry.Name] = entry
+	}
+	return lb, nil
+}
+
+/**
+ * Checks an agent's token, claiming the name if it's new
+ *
+ * @param name Agent's name
+ * @param token Agent's token
+ *
+ * @return An error if the name was claimed with a different token
+ */
+func (lb *leaderboard) authenticate(name, token string) error {
+	sum := sha256.Sum256([]byte(token))
+	hash := hex.EncodeToString(sum[:])
+
+	lb.mu.Lock()
+	defer lb.mu.Unlock()
+
+	entry, has := lb.entries[name]
+	if !has {
+		lb.entries[name] = &LeaderboardEntry{Name: name, TokenHash: hash}
+		return lb.save()
+	} else if entry.TokenHash != hash {
+		return errors.New("invalid token for name")
+	}
+	return nil
+}
+
+/**
+ * Records the results of a game and saves the leaderboard
+ *
+ * @param standings Final standings of the game
+ * @param winners Names of the winners
+ *
+ * @return An error if the leaderboard couldn't be saved
+ */
+func (lb *leaderboard) record(standings []game.PlayerDataSnapshot, winners []string) error {
+	lb.mu.Lock()
+	defer lb.mu.Unlock()
+
+	for _, player := range standings {
+		entry, has := lb.entries[player.Name]
+		if !has {
+			entry = &LeaderboardEntry{Name: player.Name}
+			lb.entries[player.Name] = entry
+		}
+		entry.Games++
+		entry.Points += player.Points
+	}
+	for _, winner := range winners {
+		lb.entries[winner].Wins++
+	}
+
+	return lb.save()
+}
+
+/**
+ * Gets the entries sorted by wins, then points
+ *
+ * @return Copy of the entries
+ */
+func (lb *leaderboard) sorted() []LeaderboardEntry {
+	lb.mu.Lock()
+	defer lb.mu.Unlock()
+
+	entries := make([]LeaderboardEntry, 0, len(lb.entries))
+	for _, entry := range lb.entries {
+		entries = append(entries, *entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Wins != entries[j].Wins {
+			return entries[i].Wins > entries[j].Wins
+		} else if entries[i].Points != entries[j].Points {
+			return entries[i].Points > entries[j].Points
+		}
+		return entries[i].Name < entries[j].Name
+	})
+	return entries
+}
+
+/**
+ * Writes the leaderboard to its file
+ *
+ * @note The lock must be held
+ *
+ * @return An error if the file couldn't be written
+ */
+func (lb *leaderboard) save() error {
+	if lb.path == "" {
+		return nil
+	}
+
+	entries := make([]*LeaderboardEntry, 0, len(lb.entries))
+	for _, entry := range lb.entries {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name < entries[j].Name })
+
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// Write then rename so a crash never leaves a partial file
+	tmp := lb.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, lb.path)
+}
+
+/**
+ * Creates a table of the leaderboard
+ *
+ * @param entries Sorted leaderboard entries
+ *
+ * @return The leaderboard table
+ */
+func leaderboardTable(entries []LeaderboardEntry) *table.Table {
+	rankHdr := i18n.T(i18n.HDR_RANK)
+	nameHdr := i18n.T(i18n.HDR_AGENT)
+	gamesHdr := i18n.T(i18n.HDR_GAMES)
+	winsHdr := i18n.T(i18n.HDR_WINS)
+	pointsHdr := i18n.T(i18n.HDR_POINTS)
+
+	t := new(table.Table)
+	t.Title = i18n.T(i18n.TITLE_LEADERBOARD)
+	t.CreateColumn(rankHdr, table.RIGHT, 0)
+	t.CreateColumn(nameHdr, table.LEFT, 0)
+	t.CreateColumn(gamesHdr, table.RIGHT, 0)
+	t.CreateColumn(winsHdr, table.RIGHT, 0)
+	t.CreateColumn(pointsHdr, table.RIGHT, 0)
+
+	for idx, entry := range entries {
+		t.AddEntry(map[string]any{
+			rankHdr:   idx + 1,
+			nameHdr:   entry.Name,
+			gamesHdr:  entry.Games,
+			winsHdr:   entry.Wins,
+			pointsHdr: entry.Points,
+		})
+	}
+	return t
+}
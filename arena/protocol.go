@@ -0,0 +1,60 @@
+package arena
+
+import "github.com/Sparhawk96/bank-ais/game"
+
+//////////////////////////////////////////////
+//                                          //
+// Newline delimited JSON messages sent     //
+// between the arena and remote agents      //
+//                                          //
+//////////////////////////////////////////////
+
+// Message types sent by agents
+const (
+	HELLO    = "hello"    // First message, with the agent's name and token
+	DECISION = "decision" // Reply to a snapshot, with the action and the snapshot's seq
+)
+
+// Message types sent by the arena
+const (
+	WELCOME  = "welcome"  // Agent is authenticated and waiting for a game
+	START    = "start"    // Agent was matched into a game
+	SNAPSHOT = "snapshot" // Agent must reply with a decision before the deadline
+	END      = "end"      // Game is over, the agent waits for its next game
+	ERROR    = "error"    // Something went wrong, the connection is closed afterwards
+)
+
+// Actions an agent can decide on
+const (
+	BANK = "bank"
+	HOLD = "hold"
+)
+
+type Message struct {
+	Type string `json:"type"`
+
+	// HELLO
+	Name  string `json:"name,omitempty"`
+	Token string `json:"token,omitempty"`
+
+	// START, SNAPSHOT and END
+	Game string `json:"game,omitempty"`
+
+	// START
+	Players []string `json:"players,omitempty"`
+
+	// SNAPSHOT and DECISION
+	Seq        int                      `json:"seq,omitempty"`
+	Snapshot   *game.BankDataSnapshot   `json:"snapshot,omitempty"` // Every other player
+	Self       *game.PlayerDataSnapshot `json:"self,omitempty"`
+	DeadlineMs int64                    `json:"deadlineMs,omitempty"`
+	Action     string                   `json:"action,omitempty"`
+
+	// END
+	Standings []game.PlayerDataSnapshot `json:"standings,omitempty"`
+	Winners   []string                  `json:"winners,omitempty"`
+	Faults    []game.Fault              `json:"faults,omitempty"`
+
+	// ERROR
+	Error string `json:"error,omitempty"`
+}
@@ -10,6 +10,9 @@ const (
 	DOT rune = '\u25CF' // ●
 )
 
+// Rolls at the start of a round where a 7 is worth 70 points instead of ending the round
+const SAFE_ROLLS = 3
+
 // Valid Values are from 1-6
 type Die uint8
 type Dice [2]Die
@@ -22,8 +25,8 @@ type Dice [2]Die
  * @return A copy of the Dice Roll
  */
 func (d *Dice) roll(r *rand.Rand) Dice {
-	d[0] = Die(r.Intn(5) + 1)
-	d[1] = Die(r.Intn(5) + 1)
+	d[0] = Die(r.Intn(6) + 1)
+	d[1] = Die(r.Intn(6) + 1)
 	return *d
 }
 
@@ -49,7 +52,7 @@ func (d Dice) Points(rollNum int, currPoints uint) (uint, bool) {
 	points := uint(num)
 
 	// Safe Rolls 1-3
-	if rollNum <= 3 {
+	if rollNum <= SAFE_ROLLS {
 		if num == 7 {
 			points = 70
 		}
@@ -0,0 +1,154 @@
+package game
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// Every die value that can be rolled
+var faces = []Die{1, 2, 3, 4, 5, 6}
+
+func TestPointsSafeRolls(t *testing.T) {
+	for rollNum := 1; rollNum <= 3; rollNum++ {
+		for _, d0 := range faces {
+			for _, d1 := range faces {
+				for _, currPoints := range []uint{0, 12, 70, 500} {
+					dice := Dice{d0, d1}
+					expected := currPoints + uint(d0+d1)
+					if d0+d1 == 7 {
+						expected = currPoints + 70
+					}
+
+					points, keepRolling := dice.Points(rollNum, currPoints)
+					if !keepRolling {
+						t.Errorf("%v roll %d: ended the round on a safe roll", dice, rollNum)
+					}
+					if points != expected {
+						t.Errorf("%v roll %d with %d points: got %d points, expected %d",
+							dice, rollNum, currPoints, points, expected)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestPointsAfterSafeRolls(t *testing.T) {
+	for rollNum := 4; rollNum <= 30; rollNum++ {
+		for _, d0 := range faces {
+			for _, d1 := range faces {
+				for _, currPoints := range []uint{0, 12, 70, 500} {
+					dice := Dice{d0, d1}
+
+					points, keepRolling := dice.Points(rollNum, currPoints)
+					switch {
+					case d0+d1 == 7:
+						if keepRolling || points != 0 {
+							t.Errorf("%v roll %d: got (%d, %t), expected the round to end with (0, false)",
+								dice, rollNum, points, keepRolling)
+						}
+					case d0 == d1:
+						if !keepRolling || points != currPoints*2 {
+							t.Errorf("%v roll %d with %d points: got (%d, %t), expected doubles (%d, true)",
+								dice, rollNum, currPoints, points, keepRolling, currPoints*2)
+						}
+					default:
+						if !keepRolling || points != currPoints+uint(d0+d1) {
+							t.Errorf("%v roll %d with %d points: got (%d, %t), expected (%d, true)",
+								dice, rollNum, currPoints, points, keepRolling, currPoints+uint(d0+d1))
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestPointsExamples(t *testing.T) {
+	tests := []struct {
+		name        string
+		dice        Dice
+		rollNum     int
+		currPoints  uint
+		points      uint
+		keepRolling bool
+	}{
+		{"first roll seven", Dice{3, 4}, 1, 0, 70, true},
+		{"third roll seven", Dice{6, 1}, 3, 20, 90, true},
+		{"safe doubles aren't doubled", Dice{5, 5}, 2, 40, 50, true},
+		{"snake eyes after safe rolls", Dice{1, 1}, 4, 85, 170, true},
+		{"boxcars after safe rolls", Dice{6, 6}, 9, 300, 600, true},
+		{"seven after safe rolls", Dice{2, 5}, 4, 300, 0, false},
+		{"eleven after safe rolls", Dice{5, 6}, 6, 100, 111, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			points, keepRolling := test.dice.Points(test.rollNum, test.currPoints)
+			if points != test.points || keepRolling != test.keepRolling {
+				t.Errorf("got (%d, %t), expected (%d, %t)", points, keepRolling, test.points, test.keepRolling)
+			}
+		})
+	}
+}
+
+func FuzzPoints(f *testing.F) {
+	f.Add(uint8(3), uint8(4), 1, uint(0))
+	f.Add(uint8(3), uint8(4), 4, uint(250))
+	f.Add(uint8(6), uint8(6), 4, uint(90))
+	f.Add(uint8(2), uint8(2), 2, uint(10))
+
+	f.Fuzz(func(t *testing.T, a, b uint8, rollNum int, currPoints uint) {
+		dice := Dice{faces[a%6], faces[b%6]}
+		rollNum = 1 + (rollNum%1000+1000)%1000
+		currPoints %= 1 << 40
+
+		points, keepRolling := dice.Points(rollNum, currPoints)
+
+		// The pot never decreases unless the round ends
+		if keepRolling && points < currPoints {
+			t.Errorf("%v roll %d: pot decreased from %d to %d", dice, rollNum, currPoints, points)
+		}
+
+		// Only a seven after the safe rolls ends the round, and it takes the whole pot
+		if !keepRolling && (rollNum <= 3 || dice[0]+dice[1] != 7 || points != 0) {
+			t.Errorf("%v roll %d: unexpected end of round with %d points", dice, rollNum, points)
+		}
+
+		// Safe sevens are worth 70
+		if rollNum <= 3 && dice[0]+dice[1] == 7 && points != currPoints+70 {
+			t.Errorf("%v roll %d: safe seven added %d points, expected 70", dice, rollNum, points-currPoints)
+		}
+
+		// Doubles only double the pot after the safe rolls
+		if dice[0] == dice[1] {
+			doubled := points == currPoints*2
+			if rollNum <= 3 && doubled && currPoints != uint(dice[0]+dice[1]) {
+				t.Errorf("%v roll %d: doubles doubled the pot on a safe roll", dice, rollNum)
+			} else if rollNum > 3 && !doubled {
+				t.Errorf("%v roll %d: doubles didn't double the pot of %d, got %d", dice, rollNum, currPoints, points)
+			}
+		}
+	})
+}
+
+func TestRollFaces(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	seen := make(map[Die]int)
+
+	for i := 0; i < 6000; i++ {
+		dice := new(Dice).roll(r)
+		for _, die := range dice {
+			if die < 1 || 6 < die {
+				t.Fatalf("rolled an invalid die: %d", die)
+			}
+			seen[die]++
+		}
+	}
+
+	for _, face := range faces {
+		if seen[face] == 0 {
+			t.Errorf("never rolled a %d", face)
+		}
+	}
+}
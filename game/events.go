@@ -0,0 +1,174 @@
+package game
+
+import (
+	"errors"
+	"time"
+)
+
+/**
+ * Gets notified of what happens in a game.
+ *
+ * @note Observers are called synchronously on the game's goroutine in the order
+ *       they were added. Event payloads are copies so they can be kept safely.
+ */
+type Observer interface {
+	/**
+	 * Called once when the game starts, before the first roll
+	 */
+	OnGameStart(GameStartEvent)
+
+	/**
+	 * Called after every roll, including the roll that ends a round
+	 */
+	OnRoll(RollEvent)
+
+	/**
+	 * Called every time a player banks
+	 */
+	OnBank(BankEvent)
+
+	/**
+	 * Called when a round ends because all players banked or a 7 was rolled
+	 */
+	OnRoundEnd(RoundEndEvent)
+
+	/**
+	 * Called once when the last round is over
+	 */
+	OnGameEnd(GameEndEvent)
+
+	/**
+	 * Called when the host undoes the last bank action
+	 */
+	OnUndo(UndoEvent)
+
+	/**
+	 * Called when a player joins after the game started or leaves
+	 */
+	OnSeatChange(SeatEvent)
+
+	/**
+	 * Called when an AI Agent decides if it will bank, or runs out of time to decide
+	 */
+	OnDecision(DecisionEvent)
+
+	/**
+	 * Called every time an AI Agent faults
+	 */
+	OnFault(Fault)
+}
+
+/**
+ * Observer that ignores every event. Embed it to only implement the events needed.
+ */
+type NopObserver struct{}
+
+func (NopObserver) OnGameStart(GameStartEvent) {}
+func (NopObserver) OnRoll(RollEvent)           {}
+func (NopObserver) OnBank(BankEvent)           {}
+func (NopObserver) OnRoundEnd(RoundEndEvent)   {}
+func (NopObserver) OnGameEnd(GameEndEvent)     {}
+func (NopObserver) OnUndo(UndoEvent)           {}
+func (NopObserver) OnSeatChange(SeatEvent)     {}
+func (NopObserver) OnDecision(DecisionEvent)   {}
+func (NopObserver) OnFault(Fault)              {}
+
+type GameStartEvent struct {
+	Seed    int64                `json:"seed"`
+	Players []PlayerDataSnapshot `json:"players"`
+}
+
+type RollEvent struct {
+	Round      uint8 `json:"round"`      // 0 based like BankDataSnapshot.CurrentRound
+	RollNumber int   `json:"rollNumber"` // 1 based roll in the round
+	Dice       Dice  `json:"dice"`
+	Points     uint  `json:"points"` // Round points after the roll, or the points lost if it busted
+	Busted     bool  `json:"busted"` // True if the roll ended the round
+}
+
+type BankEvent struct {
+	Round      uint8  `json:"round"`
+	RollNumber int    `json:"rollNumber"`
+	Player     string `json:"player"`
+	AiAgent    bool   `json:"aiAgent"`
+	Points     uint   `json:"points"` // Points banked
+	Total      uint   `json:"total"`  // Player's total points after banking
+}
+
+type RoundEndEvent struct {
+	Round     uint8                `json:"round"`
+	Rolls     []Dice               `json:"rolls"`
+	Points    uint                 `json:"points"` // Round points when the round ended
+	Busted    bool                 `json:"busted"` // True if a 7 ended the round, otherwise all players banked
+	Standings []PlayerDataSnapshot `json:"standings"`
+
+	KnockedOut string `json:"knockedOut,omitempty"` // Player knocked out at the end of the round, if any
+}
+
+type GameEndEvent struct {
+	Standings []PlayerDataSnapshot `json:"standings"`
+	Teams     []TeamDataSnapshot   `json:"teams,omitempty"` // Teams in ranking order, if playing with teams
+	Winners   []string             `json:"winners"`
+	Faults    []Fault              `json:"faults,omitempty"` // Every fault made by the AI Agents
+}
+
+type UndoEvent struct {
+	Undo
+	Standings []PlayerDataSnapshot `json:"standings"` // Standings after the banks were undone
+}
+
+type SeatEvent struct {
+	Round     uint8                `json:"round"`
+	Player    string               `json:"player"`
+	Change    SeatChange           `json:"change"`
+	Points    uint                 `json:"points"` // Points the player has after the change
+	Standings []PlayerDataSnapshot `json:"standings"`
+}
+
+type DecisionEvent struct {
+	Round      uint8         `json:"round"`
+	RollNumber int           `json:"rollNumber"`
+	Player     string        `json:"player"`
+	Bank       bool          `json:"bank"`
+	Latency    time.Duration `json:"latency"`         // Time the agent took to decide, the timeout if it ran out of time
+	Fault      FaultKind     `json:"fault,omitempty"` // Kind of fault if the agent failed to decide
+}
+
+/**
+ * Adds an observer to be notified of game events
+ *
+ * @param observer Observer to add
+ *
+ * @return An error if the observer is nil
+ */
+func (g *Game) AddObserver(observer Observer) error {
+	if observer == nil {
+		return errors.New("added nil observer")
+	}
+	g.observers = append(g.observers, observer)
+	return nil
+}
+
+/**
+ * Sends an event to every observer
+ *
+ * @param notify Calls the observer method for the event
+ */
+func (g *Game) notify(notify func(Observer)) {
+	for _, observer := range g.observers {
+		notify(observer)
+	}
+}
+
+/**
+ * Gets the data of every player in ranking order
+ *
+ * @return Snapshot of every player
+ */
+func (r *results) standings() []PlayerDataSnapshot {
+	standings := make([]PlayerDataSnapshot, 0, len(r.players))
+	for _, player := range r.rankedPlayers() {
+		standings = append(standings, r.getPlayerData(player))
+	}
+	return standings
+}
@@ -0,0 +1,98 @@
+package game
+
+import "testing"
+
+/**
+ * Observer that keeps every event it's sent
+ */
+type recordingObserver struct {
+	events []any
+}
+
+func (o *recordingObserver) OnGameStart(e GameStartEvent) { o.events = append(o.events, e) }
+func (o *recordingObserver) OnRoll(e RollEvent)           { o.events = append(o.events, e) }
+func (o *recordingObserver) OnBank(e BankEvent)           { o.events = append(o.events, e) }
+func (o *recordingObserver) OnRoundEnd(e RoundEndEvent)   { o.events = append(o.events, e) }
+func (o *recordingObserver) OnGameEnd(e GameEndEvent)     { o.events = append(o.events, e) }
+func (o *recordingObserver) OnUndo(e UndoEvent)           { o.events = append(o.events, e) }
+func (o *recordingObserver) OnSeatChange(e SeatEvent)     { o.events = append(o.events, e) }
+func (o *recordingObserver) OnDecision(e DecisionEvent)   { o.events = append(o.events, e) }
+func (o *recordingObserver) OnFault(e Fault)              { o.events = append(o.events, e) }
+
+func TestObserverEvents(t *testing.T) {
+	g := NewGame()
+	g.SetSeed(42)
+	g.AddPlayer(thresholdAgent{"Timid", 50})
+	g.AddPlayer(thresholdAgent{"Greedy", 300})
+
+	observer := new(recordingObserver)
+	if err := g.AddObserver(observer); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.AddObserver(nil); err == nil {
+		t.Error("expected an error adding a nil observer")
+	}
+
+	playQuietly(t, g)
+
+	if start, isStart := observer.events[0].(GameStartEvent); !isStart || start.Seed != 42 || len(start.Players) != 2 {
+		t.Fatalf("expected the game start first, got %+v", observer.events[0])
+	}
+	end, isEnd := observer.events[len(observer.events)-1].(GameEndEvent)
+	if !isEnd || len(end.Winners) != 1 || end.Winners[0] != end.Standings[0].Name {
+		t.Fatalf("expected the game end last, got %+v", observer.events[len(observer.events)-1])
+	}
+
+	var rolls, roundEnds int
+	totals := make(map[string]uint)
+	for _, event := range observer.events {
+		switch e := event.(type) {
+		case RollEvent:
+			rolls++
+			if e.RollNumber < 1 || int(e.Round) != roundEnds {
+				t.Errorf("roll out of order: %+v", e)
+			}
+		case BankEvent:
+			totals[e.Player] += e.Points
+			if totals[e.Player] != e.Total || !e.AiAgent {
+				t.Errorf("unexpected bank: %+v", e)
+			}
+		case RoundEndEvent:
+			if int(e.Round) != roundEnds || len(e.Rolls) != len(g.rounds[e.Round].rolls) {
+				t.Errorf("unexpected round end: %+v", e)
+			}
+			roundEnds++
+		}
+	}
+
+	if roundEnds != MAX_ROUNDS {
+		t.Errorf("expected %d round ends, got %d", MAX_ROUNDS, roundEnds)
+	}
+
+	expectedRolls := 0
+	for _, round := range g.rounds {
+		expectedRolls += len(round.rolls)
+	}
+	if rolls != expectedRolls {
+		t.Errorf("expected %d roll events, got %d", expectedRolls, rolls)
+	}
+
+	for _, player := range end.Standings {
+		if totals[player.Name] != player.Points {
+			t.Errorf("%s banked %d points in events but finished with %d", player.Name, totals[player.Name], player.Points)
+		}
+	}
+}
+
+func TestNopObserver(t *testing.T) {
+	type countRolls struct {
+		NopObserver
+	}
+
+	g := NewGame()
+	g.AddPlayer(thresholdAgent{"Steady", 100})
+	if err := g.AddObserver(countRolls{}); err != nil {
+		t.Fatal(err)
+	}
+	playQuietly(t, g)
+}
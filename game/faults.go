@@ -0,0 +1,426 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Sparhawk96/bank-ais/i18n"
+	"github.com/Sparhawk96/bank-ais/table"
+)
+
+// Time an AI Agent has to decide when no other timeout is set
+const DEFAULT_AGENT_TIMEOUT = 2 * time.Second
+
+type FaultKind string
+
+const (
+	FAULT_TIMEOUT          FaultKind = "timeout"
+	FAULT_PANIC            FaultKind = "panic"
+	FAULT_INVALID_RESPONSE FaultKind = "invalid response"
+)
+
+// Translated names of the fault kinds
+var faultKindKeys = map[FaultKind]i18n.Key{
+	FAULT_TIMEOUT:          i18n.CELL_FAULT_TIMEOUT,
+	FAULT_PANIC:            i18n.CELL_FAULT_PANIC,
+	FAULT_INVALID_RESPONSE: i18n.CELL_FAULT_INVALID,
+}
+
+/**
+ * Returned by a FallibleAgent that ran out of time to decide
+ */
+var ErrAgentTimeout = errors.New("agent ran out of time to decide")
+
+/**
+ * AI Agent whose decision can fail, such as one playing over a network.
+ *
+ * @note When implemented, TryBank is called instead of Bank. An ErrAgentTimeout
+ *       error is recorded as a timeout, any other error as an invalid response.
+ */
+type FallibleAgent interface {
+	Player
+
+	/**
+	 * Dictates if the player will bank or not
+	 *
+	 * @return True if the player wants to bank, or an error if it failed to decide
+	 */
+	TryBank(*Game) (bool, error)
+}
+
+/**
+ * Something an AI Agent did wrong when asked to bank. The agent doesn't bank when it faults.
+ */
+type Fault struct {
+	Round        uint8     `json:"round"`      // 0 based like BankDataSnapshot.CurrentRound
+	RollNumber   int       `json:"rollNumber"` // 1 based roll in the round
+	Player       string    `json:"player"`
+	Kind         FaultKind `json:"kind"`
+	Detail       string    `json:"detail"`
+	Disqualified bool      `json:"disqualified"` // True if this fault disqualified the agent
+}
+
+type decision struct {
+	bank    bool
+	err     error
+	kind    FaultKind
+	latency time.Duration // Time the agent took to decide
+}
+
+/**
+ * Decision of an agent that is still running after its timeout
+ */
+type pendingDecision struct {
+	done     chan decision
+	reported bool // True once the agent was skipped for still deciding
+}
+
+/**
+ * What the read only views given to AI Agents answer with, copied when the view is made.
+ * The post game report isn't copied as it would be built again on every roll.
+ */
+type frozenGame struct {
+	snapshot BankDataSnapshot
+	winners  []string
+	faults   []Fault
+	undos    []Undo
+}
+
+/**
+ * Sets how long an AI Agent has to decide if it will bank
+ *
+ * @param timeout Time allowed for each decision, 0 or less to wait forever
+ *
+ * @return An error if the game has started
+ */
+func (g *Game) SetAgentTimeout(timeout time.Duration) error {
+	if g.started {
+		return errors.New(GAME_HAS_STARTED_ERR_MSG)
+	}
+	g.agentTimeout = timeout
+	return nil
+}
+
+/**
+ * Sets how many faults disqualify an AI Agent. Disqualified agents aren't asked
+ * to bank again for the rest of the game.
+ *
+ * @param faults Faults allowed before being disqualified, 0 to never disqualify
+ *
+ * @return An error if the game has started
+ */
+func (g *Game) SetMaxFaults(faults int) error {
+	if g.started {
+		return errors.New(GAME_HAS_STARTED_ERR_MSG)
+	}
+	g.maxFaults = faults
+	return nil
+}
+
+/**
+ * Gets every fault made by the AI Agents thus far
+ *
+ * @return Copy of the fault log
+ */
+func (g *Game) Faults() []Fault {
+	if g.frozen != nil {
+		return append([]Fault(nil), g.frozen.faults...)
+	}
+	return append([]Fault(nil), g.results.faults...)
+}
+
+/**
+ * Asks AI Agents at the same time if they will bank, isolating the game from
+ * agents that panic or take too long. Faults are recorded in the order of the
+ * agents and treated as not banking.
+ *
+ * @param agents AI Agents to ask
+ *
+ * @return Whether each agent wants to bank, in the same order as the agents
+ */
+func (g *Game) decideAll(agents []Player) []bool {
+	view := g.view() // Every agent decides from the same snapshot
+	pending := make([]chan decision, len(agents))
+	for idx, agent := range agents {
+		pending[idx] = g.ask(agent, view)
+	}
+
+	ctx := context.Background()
+	if g.agentTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, g.agentTimeout)
+		defer cancel()
+	}
+
+	decisions := make([]bool, len(agents))
+	for idx, agent := range agents {
+		if pending[idx] == nil {
+			continue
+		}
+
+		d, decided := waitForDecision(ctx, pending[idx])
+		if !decided {
+			g.notifyDecision(agent, decision{err: ErrAgentTimeout, kind: FAULT_TIMEOUT, latency: g.agentTimeout})
+			g.deciding[agent.Name()] = &pendingDecision{done: pending[idx]}
+			g.fault(agent, FAULT_TIMEOUT, fmt.Sprintf("no decision within %s", g.agentTimeout))
+			continue
+		}
+
+		g.logDecision(agent, d)
+		g.notifyDecision(agent, d)
+		if d.err != nil {
+			g.fault(agent, d.kind, d.err.Error())
+		}
+		decisions[idx] = d.err == nil && d.bank
+	}
+	return decisions
+}
+
+/**
+ * Waits for an agent's decision until the deadline
+ *
+ * @note A decision made in time is always taken, even if the deadline passed
+ *       while waiting on slower agents asked before it
+ *
+ * @param ctx Context ending at the deadline
+ * @param pending Receives the agent's decision
+ *
+ * @return The decision and true, or false if the agent didn't decide in time
+ */
+func waitForDecision(ctx context.Context, pending <-chan decision) (decision, bool) {
+	select {
+	case d := <-pending:
+		return d, true
+	default:
+	}
+
+	select {
+	case d := <-pending:
+		return d, true
+	case <-ctx.Done():
+		return decision{}, false
+	}
+}
+
+/**
+ * Starts asking an AI Agent if it will bank
+ *
+ * @param agent AI Agent to ask
+ * @param view Frozen copy of the game for the agent
+ *
+ * @return Channel receiving the decision, or nil if the agent isn't asked
+ */
+func (g *Game) ask(agent Player, view *Game) chan decision {
+	if g.results.disqualified(agent) {
+		return nil
+	}
+
+	// An agent still deciding from an earlier roll isn't asked again,
+	// which is only a fault the first time it's skipped
+	if busy, has := g.deciding[agent.Name()]; has {
+		select {
+		case <-busy.done:
+			delete(g.deciding, agent.Name())
+		default:
+			if !busy.reported {
+				busy.reported = true
+				g.fault(agent, FAULT_TIMEOUT, "still deciding an earlier roll")
+			}
+			return nil
+		}
+	}
+
+	done := make(chan decision, 1)
+	go askAgent(agent, view, done)
+	return done
+}
+
+/**
+ * Calls the agent, recovering if it panics
+ *
+ * @param player AI Agent to ask
+ * @param view Frozen copy of the game for the agent
+ * @param done Receives the decision
+ */
+func askAgent(player Player, view *Game, done chan<- decision) {
+	start := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			done <- decision{err: fmt.Errorf("%v", r), kind: FAULT_PANIC, latency: time.Since(start)}
+		}
+	}()
+
+	if fallible, ok := player.(FallibleAgent); ok {
+		bank, err := fallible.TryBank(view)
+		switch {
+		case errors.Is(err, ErrAgentTimeout):
+			done <- decision{err: err, kind: FAULT_TIMEOUT, latency: time.Since(start)}
+		case err != nil:
+			done <- decision{err: err, kind: FAULT_INVALID_RESPONSE, latency: time.Since(start)}
+		default:
+			done <- decision{bank: bank, latency: time.Since(start)}
+		}
+		return
+	}
+	done <- decision{bank: player.Bank(view), latency: time.Since(start)}
+}
+
+/**
+ * Records a fault, disqualifying the agent if it has faulted too many times
+ *
+ * @param player AI Agent who faulted
+ * @param kind Kind of fault
+ * @param detail What went wrong
+ */
+func (g *Game) fault(player Player, kind FaultKind, detail string) {
+	round := &g.rounds[g.currentRound]
+	g.results.recordFault(Fault{
+		Round:      g.currentRound,
+		RollNumber: len(round.rolls),
+		Player:     player.Name(),
+		Kind:       kind,
+		Detail:     detail,
+	}, g.maxFaults)
+
+	fault := g.results.faults[len(g.results.faults)-1]
+	g.logger.Warn("fault",
+		"round", fault.Round+1,
+		"roll", fault.RollNumber,
+		"player", fault.Player,
+		"kind", fault.Kind,
+		"detail", fault.Detail,
+		"disqualified", fault.Disqualified,
+	)
+	g.notify(func(o Observer) {
+		o.OnFault(fault)
+	})
+}
+
+/**
+ * Notifies the observers of an AI Agent's decision
+ *
+ * @param player AI Agent who decided
+ * @param d Decision of the agent
+ */
+func (g *Game) notifyDecision(player Player, d decision) {
+	event := DecisionEvent{
+		Round:      g.currentRound,
+		RollNumber: len(g.rounds[g.currentRound].rolls),
+		Player:     player.Name(),
+		Bank:       d.err == nil && d.bank,
+		Latency:    d.latency,
+	}
+	if d.err != nil {
+		event.Fault = d.kind
+	}
+	g.notify(func(o Observer) {
+		o.OnDecision(event)
+	})
+}
+
+/**
+ * Creates a read only copy of the game for AI Agents to decide with, so an
+ * agent still running after its timeout can't see the game change under it
+ *
+ * @return Game that answers with what the game was when the view was made
+ */
+func (g *Game) view() *Game {
+	frozen := &frozenGame{
+		snapshot: g.snapshot(""),
+		winners:  g.Winners(),
+		faults:   g.Faults(),
+		undos:    g.Undos(),
+	}
+
+	players := make(map[string]Player, len(g.players))
+	for name, player := range g.players {
+		players[name] = player
+	}
+
+	return &Game{
+		started:      g.started,
+		over:         g.over,
+		currentRound: g.currentRound,
+		mode:         g.mode,
+		players:      players,
+		order:        append([]Player(nil), g.order...),
+		seed:         g.seed,
+		teamRule:     g.teamRule,
+		host:         g.host,
+		joining:      append([]joiner(nil), g.joining...),
+		agentTimeout: g.agentTimeout,
+		maxFaults:    g.maxFaults,
+		frozen:       frozen,
+	}
+}
+
+/**
+ * Records a fault against a player
+ *
+ * @param fault Fault to record
+ * @param maxFaults Faults allowed before being disqualified, 0 to never disqualify
+ */
+func (r *results) recordFault(fault Fault, maxFaults int) {
+	pn := r.players[fault.Player]
+	pn.faults++
+	if maxFaults > 0 && pn.faults >= maxFaults && !pn.disqualified {
+		pn.disqualified = true
+		fault.Disqualified = true
+	}
+	r.faults = append(r.faults, fault)
+}
+
+/**
+ * Dictates if a player was disqualified for faulting
+ *
+ * @param player To check if they are disqualified
+ *
+ * @return True if the player is disqualified, otherwise false
+ */
+func (r *results) disqualified(player Player) bool {
+	return r.players[player.Name()].disqualified
+}
+
+/**
+ * Creates a table of the fault log
+ *
+ * @param faults Faults to list
+ *
+ * @return The fault table
+ */
+func faultTable(faults []Fault) *table.Table {
+	roundHdr := i18n.T(i18n.HDR_ROUND)
+	rollHdr := i18n.T(i18n.HDR_ROLL)
+	playerHdr := i18n.T(i18n.HDR_PLAYER)
+	kindHdr := i18n.T(i18n.HDR_FAULT)
+	detailHdr := i18n.T(i18n.HDR_DETAIL)
+
+	t := new(table.Table)
+	t.Title = i18n.T(i18n.TITLE_FAULTS)
+	t.CreateColumn(roundHdr, table.RIGHT, 0)
+	t.CreateColumn(rollHdr, table.RIGHT, 0)
+	t.CreateColumn(playerHdr, table.LEFT, 0)
+	t.CreateColumn(kindHdr, table.LEFT, 0)
+	t.CreateColumn(detailHdr, table.LEFT, 0)
+	t.SetMaxWidth(detailHdr, 40, table.ELLIPSIS)
+
+	for _, fault := range faults {
+		kind := string(fault.Kind)
+		if key, has := faultKindKeys[fault.Kind]; has {
+			kind = i18n.T(key)
+		}
+		if fault.Disqualified {
+			kind = i18n.T(i18n.CELL_DISQUALIFIED, kind)
+		}
+		t.AddEntry(map[string]any{
+			roundHdr:  int(fault.Round) + 1,
+			rollHdr:   fault.RollNumber,
+			playerHdr: fault.Player,
+			kindHdr:   kind,
+			detailHdr: fault.Detail,
+		})
+	}
+	return t
+}
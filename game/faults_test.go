@@ -0,0 +1,363 @@
+package game
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+/**
+ * Agent that misbehaves in a given way every time it's asked to bank
+ */
+type faultyAgent struct {
+	name      string
+	misbehave func(g *Game) (bool, error)
+}
+
+func (a faultyAgent) Name() string {
+	return a.name
+}
+
+func (a faultyAgent) Bank(g *Game) bool {
+	bank, _ := a.TryBank(g)
+	return bank
+}
+
+func (a faultyAgent) TryBank(g *Game) (bool, error) {
+	return a.misbehave(g)
+}
+
+func (a faultyAgent) AiAgent() bool {
+	return true
+}
+
+func TestAgentFaults(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	cases := []struct {
+		name      string
+		misbehave func(g *Game) (bool, error)
+		kind      FaultKind
+	}{
+		{"Panics", func(*Game) (bool, error) { panic("boom") }, FAULT_PANIC},
+		{"Blocks", func(*Game) (bool, error) { <-release; return true, nil }, FAULT_TIMEOUT},
+		{"Late", func(*Game) (bool, error) { return true, ErrAgentTimeout }, FAULT_TIMEOUT},
+		{"Garbled", func(*Game) (bool, error) { return true, errors.New("unknown action") }, FAULT_INVALID_RESPONSE},
+		{"Cheats", func(g *Game) (bool, error) { _, err := g.Roll(); return err == nil, err }, FAULT_INVALID_RESPONSE},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewGame()
+			g.SetSeed(42)
+			g.SetAgentTimeout(10 * time.Millisecond)
+			g.AddPlayer(thresholdAgent{"Timid", 50})
+			g.AddPlayer(faultyAgent{tc.name, tc.misbehave})
+
+			playQuietly(t, g)
+
+			faults := g.Faults()
+			if len(faults) == 0 {
+				t.Fatal("expected faults to be recorded")
+			}
+			for _, fault := range faults {
+				if fault.Player != tc.name || fault.Kind != tc.kind || fault.Disqualified {
+					t.Errorf("unexpected fault: %+v", fault)
+				}
+			}
+
+			// Faulting is the same as never banking
+			for _, pr := range g.Report().Players {
+				if pr.Name == tc.name && pr.Points != 0 {
+					t.Errorf("faulty agent has %d points", pr.Points)
+				}
+			}
+		})
+	}
+}
+
+func TestAgentDisqualified(t *testing.T) {
+	asked := 0
+	g := NewGame()
+	g.SetSeed(42)
+	g.SetMaxFaults(3)
+	g.AddPlayer(thresholdAgent{"Timid", 50})
+	g.AddPlayer(faultyAgent{"Panics", func(*Game) (bool, error) { asked++; panic("boom") }})
+
+	playQuietly(t, g)
+
+	if asked != 3 {
+		t.Errorf("disqualified agent was asked %d times, expected 3", asked)
+	}
+	faults := g.Faults()
+	if len(faults) != 3 || !faults[2].Disqualified || faults[1].Disqualified {
+		t.Errorf("expected the third fault to disqualify, got %+v", faults)
+	}
+
+	report := g.Report()
+	if len(report.Faults) != 3 {
+		t.Errorf("expected the report to include the faults, got %+v", report.Faults)
+	}
+	if out := report.String(); !strings.Contains(out, "Panic, disqualified") {
+		t.Errorf("expected the fault table in the report:\n%s", out)
+	}
+	if out := g.results.String(); !strings.Contains(out, "3 DQ") {
+		t.Errorf("expected the disqualification in the results:\n%s", out)
+	}
+}
+
+func TestAgentsSeeFrozenGame(t *testing.T) {
+	var seen []BankDataSnapshot
+	g := NewGame()
+	g.SetSeed(42)
+	g.AddPlayer(thresholdAgent{"Timid", 50})
+	g.AddPlayer(faultyAgent{"Watcher", func(view *Game) (bool, error) {
+		seen = append(seen, view.GetData(thresholdAgent{name: "Watcher"}))
+		return false, nil
+	}})
+
+	if err := g.Begin(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := g.Roll(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(seen) != 1 || len(seen[0].Players) != 1 || seen[0].Players[0].Name != "Timid" {
+		t.Fatalf("expected a snapshot without the agent, got %+v", seen)
+	}
+	if state := g.State(); seen[0].RoundPoints != state.RoundPoints || seen[0].RollNumber != state.RollNumber {
+		t.Errorf("agent saw %+v, expected %+v", seen[0], state)
+	}
+	if len(g.Faults()) != 0 {
+		t.Errorf("unexpected faults: %+v", g.Faults())
+	}
+}
+
+/**
+ * Agent that banks once any other player has banked this round
+ */
+type followerAgent struct {
+	name string
+}
+
+func (a followerAgent) Name() string {
+	return a.name
+}
+
+func (a followerAgent) Bank(g *Game) bool {
+	for _, player := range g.GetData(a).Players {
+		if player.Banked {
+			return true
+		}
+	}
+	return false
+}
+
+func (a followerAgent) AiAgent() bool {
+	return true
+}
+
+func TestAgentsDecideTogether(t *testing.T) {
+	// Each agent waits for the other to be asked, which only works if they're asked at the same time
+	var arrived sync.WaitGroup
+	arrived.Add(2)
+	meet := func(*Game) (bool, error) {
+		arrived.Done()
+		arrived.Wait()
+		return true, nil
+	}
+
+	g := NewGame()
+	g.SetSeed(42)
+	g.SetAgentTimeout(time.Second)
+	g.AddPlayer(faultyAgent{"Ann", meet})
+	g.AddPlayer(faultyAgent{"Bob", meet})
+
+	observer := new(recordingObserver)
+	g.AddObserver(observer)
+	g.Begin()
+	g.Roll()
+
+	if faults := g.Faults(); len(faults) != 0 {
+		t.Fatalf("agents weren't asked together: %+v", faults)
+	}
+
+	// Banks are applied in the order the players were added
+	banks := make([]string, 0)
+	for _, event := range observer.events {
+		if bank, isBank := event.(BankEvent); isBank {
+			banks = append(banks, bank.Player)
+		}
+	}
+	if len(banks) != 2 || banks[0] != "Ann" || banks[1] != "Bob" {
+		t.Errorf("expected Ann then Bob to bank, got %v", banks)
+	}
+}
+
+func TestAgentsSeeSameSnapshot(t *testing.T) {
+	var mu sync.Mutex
+	seen := make(map[string]BankDataSnapshot)
+	record := func(name string) func(*Game) (bool, error) {
+		return func(view *Game) (bool, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			if _, has := seen[name]; !has {
+				seen[name] = view.State()
+			}
+			return true, nil
+		}
+	}
+
+	g := NewGame()
+	g.SetSeed(42)
+	for _, name := range []string{"Ann", "Bob", "Cat"} {
+		g.AddPlayer(faultyAgent{name, record(name)})
+	}
+	g.Begin()
+	g.Roll()
+
+	for name, snapshot := range seen {
+		for _, player := range snapshot.Players {
+			if player.Banked {
+				t.Errorf("%s saw %s already banked", name, player.Name)
+			}
+		}
+	}
+	if len(seen) != 3 {
+		t.Errorf("expected every agent to be asked, got %v", seen)
+	}
+}
+
+func TestAgentsReactToBanks(t *testing.T) {
+	g := NewGame()
+	g.SetSeed(42)
+	g.AddPlayer(followerAgent{"Follower"})
+	g.AddPlayer(thresholdAgent{"Timid", 1})
+
+	observer := new(recordingObserver)
+	g.AddObserver(observer)
+	g.Begin()
+	g.Roll()
+
+	banks := make([]BankEvent, 0)
+	for _, event := range observer.events {
+		if bank, isBank := event.(BankEvent); isBank {
+			banks = append(banks, bank)
+		}
+	}
+	if len(banks) != 2 || banks[0].Player != "Timid" || banks[1].Player != "Follower" {
+		t.Fatalf("expected the follower to react to Timid banking, got %+v", banks)
+	}
+	if banks[0].RollNumber != banks[1].RollNumber || banks[0].Points != banks[1].Points {
+		t.Errorf("expected the reaction on the same roll, got %+v", banks)
+	}
+}
+
+func TestViewAnswersLikeTheGame(t *testing.T) {
+	var mu sync.Mutex
+	var winners, players []string
+	var report Report
+	var errs []error
+
+	g := NewGame()
+	g.SetSeed(42)
+	g.SetMode(Mode{Rounds: 2})
+	g.AddPlayer(thresholdAgent{"Timid", 50})
+	g.AddPlayer(faultyAgent{"Curious", func(view *Game) (bool, error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		winners = view.Winners()
+		players = players[:0]
+		for _, player := range view.Players() {
+			players = append(players, player.Name())
+		}
+		report = view.Report()
+		view.Faults()
+		view.Undos()
+		view.Joining()
+		view.Host()
+		view.Mode()
+
+		// Every change is refused instead of panicking
+		errs = errs[:0]
+		_, err := view.Roll()
+		errs = append(errs, err, view.BankPlayer("Timid"), view.Forfeit("Timid"),
+			view.Replace("Timid", thresholdAgent{"Timid", 0}), view.Join(thresholdAgent{"Late", 0}, 0),
+			view.AddPlayer(thresholdAgent{"Late", 0}), view.Begin(), view.SetMode(Mode{}))
+		_, err = view.Undo("Timid")
+		errs = append(errs, err)
+		return false, nil
+	}})
+
+	playQuietly(t, g)
+
+	if faults := g.Faults(); len(faults) != 0 {
+		t.Fatalf("expected the view to never panic, got %+v", faults)
+	}
+	if len(players) != 2 || players[0] != "Timid" || players[1] != "Curious" {
+		t.Errorf("unexpected players: %v", players)
+	}
+	if len(winners) == 0 {
+		t.Error("expected the view to know who was winning")
+	}
+	if report.Rounds != 0 || len(report.Players) != 0 {
+		t.Errorf("expected the view to have no report, got %+v", report)
+	}
+	for idx, err := range errs {
+		if err == nil {
+			t.Errorf("change %d to the view wasn't refused", idx)
+		}
+	}
+}
+
+func TestStillDecidingFaultsOnce(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	g := NewGame()
+	g.SetSeed(42)
+	g.SetAgentTimeout(10 * time.Millisecond)
+	g.AddPlayer(thresholdAgent{"Timid", 50})
+	g.AddPlayer(faultyAgent{"Hung", func(*Game) (bool, error) { <-release; return true, nil }})
+
+	playQuietly(t, g)
+
+	// Timing out, then being skipped while still hung, is one fault each
+	details := make([]string, 0)
+	for _, fault := range g.Faults() {
+		details = append(details, fault.Detail)
+	}
+	if len(details) != 2 || !strings.HasPrefix(details[0], "no decision within") || details[1] != "still deciding an earlier roll" {
+		t.Errorf("expected a timeout then one still deciding fault, got %q", details)
+	}
+}
+
+func TestFastAgentNotTimedOutBySlowAgent(t *testing.T) {
+	for seed := int64(1); seed <= 5; seed++ {
+		g := NewGame()
+		g.SetSeed(seed)
+		g.SetMode(Mode{Rounds: 3})
+		g.SetAgentTimeout(5 * time.Millisecond)
+		g.AddPlayer(faultyAgent{"Slow", func(*Game) (bool, error) { time.Sleep(20 * time.Millisecond); return false, nil }})
+		g.AddPlayer(faultyAgent{"Fast", func(*Game) (bool, error) { return false, nil }})
+
+		playQuietly(t, g)
+
+		// The deadline passes while waiting on Slow, but Fast had already decided
+		slow := 0
+		for _, fault := range g.Faults() {
+			if fault.Player == "Fast" {
+				t.Fatalf("seed %d: fast agent faulted: %+v", seed, fault)
+			}
+			slow++
+		}
+		if slow == 0 {
+			t.Fatalf("seed %d: expected the slow agent to time out", seed)
+		}
+	}
+}
@@ -3,8 +3,12 @@ package game
 import (
 	"errors"
 	"fmt"
+	"log/slog"
 	"math/rand"
+	"strings"
+	"time"
 
+	"github.com/Sparhawk96/bank-ais/i18n"
 	"github.com/Sparhawk96/bank-ais/table"
 )
 
@@ -14,12 +18,34 @@ const GAME_HAS_STARTED_ERR_MSG = "game has already started"
 
 type Game struct {
 	started      bool
-	currentRound uint8 // 1 - 20
-	rounds       [20]round
+	over         bool
+	currentRound uint8 // Index of the round being played, the mode allows up to 255 rounds
+	rounds       []round
+	mode         Mode
 	players      map[string]Player
+	order        []Player // Players in the order they were added
 	results      *results
 	seed         int64
 	r            *rand.Rand
+	observers    []Observer
+	logger       *slog.Logger
+
+	teamRule TeamRule
+
+	// Undoing mistaken banks
+	host     string      // Human player who can undo banks, empty if nobody can
+	action   *bankAction // Bank action being made
+	lastBank *bankAction // Bank action that can be undone until the next roll
+
+	// Players joining and leaving after the game started
+	joining []joiner                 // Players waiting for the next round to join
+	standIn func(name string) Player // AI Agent taking over when a human leaves in the terminal
+
+	// Isolation of the AI Agents from the game
+	agentTimeout time.Duration
+	maxFaults    int
+	deciding     map[string]*pendingDecision // Agents still deciding after their timeout
+	frozen       *frozenGame                 // Only set on the read only views given to agents
 
 	// True if all of the players are AI Agents,
 	// otherwise at least one human is playing
@@ -43,10 +69,15 @@ func NewGame() *Game {
 	return &Game{
 		currentRound: 0,
 		players:      make(map[string]Player, 0),
+		rounds:       make([]round, 1),
+		mode:         Mode{Rounds: MAX_ROUNDS},
 		results:      new(results),
 		seed:         seed,
 		r:            rand.New(rand.NewSource(seed)),
 		onlyAI:       true,
+		agentTimeout: DEFAULT_AGENT_TIMEOUT,
+		deciding:     make(map[string]*pendingDecision),
+		logger:       discardLogger,
 	}
 }
 
@@ -70,98 +101,205 @@ func (g *Game) SetSeed(seed int64) error {
  * Adds a Player to the game
  *
  * @param player Player to add to the game
+ * @param handicap Optional handicap of the player
  *
- * @return An error if the player is nil, another player with the same name exists, or the game has started
+ * @return An error if the player is nil, another player with the same name exists,
+ *         the handicap isn't valid, or the game has started
  */
-func (g *Game) AddPlayer(player Player) error {
+func (g *Game) AddPlayer(player Player, handicap ...Handicap) error {
 	if g.started {
 		return errors.New(GAME_HAS_STARTED_ERR_MSG)
 	} else if player == nil {
 		return fmt.Errorf("added nil player")
 	} else if _, exists := g.players[player.Name()]; exists {
 		return fmt.Errorf("player already exists with that name: '%s'", player.Name())
+	} else if len(handicap) > 1 {
+		return errors.New("a player can only have one handicap")
+	}
+
+	var h Handicap
+	if len(handicap) == 1 {
+		if err := handicap[0].validate(); err != nil {
+			return err
+		}
+		h = handicap[0]
 	}
 
 	g.players[player.Name()] = player
+	g.order = append(g.order, player)
 	g.results.addPlayer(player)
+	g.results.setHandicap(player, h)
 	g.onlyAI = g.onlyAI && player.AiAgent()
 	return nil
 }
 
 /**
- * Starts the game of Bank
+ * Gets who is winning the game, every member of the leading teams when playing with teams
+ *
+ * @return Names of the players in first place
+ */
+func (g *Game) Winners() []string {
+	if g.frozen != nil {
+		return append([]string{}, g.frozen.winners...)
+	}
+
+	winners := make([]string, 0)
+	for _, winner := range g.results.leaders() {
+		winners = append(winners, winner.Name())
+	}
+	return winners
+}
+
+/**
+ * Gets the players of the game
+ *
+ * @return Players in the order they were added
+ */
+func (g *Game) Players() []Player {
+	return append([]Player(nil), g.order...)
+}
+
+/**
+ * Starts the game of Bank in the terminal, prompting the human players
+ * until all of the rounds are played
  *
  * @return An error if the game is already started
  */
 func (g *Game) StartGame() error {
-	if g.started {
-		return errors.New(GAME_HAS_STARTED_ERR_MSG)
+	if err := g.Begin(); err != nil {
+		return err
 	}
-	g.started = true
 
 	// Notify the players who is playing
-	playerHdr := "Player"
-	aiAgentHdr := "AI Agent"
+	playerHdr := i18n.T(i18n.HDR_PLAYER)
+	aiAgentHdr := i18n.T(i18n.HDR_AI_AGENT)
+
+	teamHdr := i18n.T(i18n.HDR_TEAM)
+	handicapHdr := i18n.T(i18n.HDR_HANDICAP)
 
 	players := new(table.Table)
 	players.CreateColumn(playerHdr, table.LEFT, 0)
 	players.CreateColumn(aiAgentHdr, table.CENTER, '\u2716') // ✖
+	if len(g.results.teams) > 0 {
+		players.CreateColumn(teamHdr, table.LEFT, 0)
+	}
+	if g.results.anyHandicaps() {
+		players.CreateColumn(handicapHdr, table.LEFT, 0)
+	}
 
-	for _, player := range g.players {
-		data := map[string]any{playerHdr: player.Name()}
+	for _, player := range g.order {
+		pn := g.results.players[player.Name()]
+		data := map[string]any{
+			playerHdr:   player.Name(),
+			handicapHdr: pn.handicap.String(),
+		}
 		if player.AiAgent() {
-			data[aiAgentHdr] = '\u2714' // ✔
+			data[aiAgentHdr] = "✔"
+		}
+		if pn.team != nil {
+			data[teamHdr] = pn.team.name
 		}
 		players.AddEntry(data)
 	}
 
-	fmt.Println("Starting Game ...")
-	fmt.Println(players)
+	fmt.Fprintln(output, i18n.T(i18n.MSG_STARTING_GAME))
+	fmt.Fprintln(output, players)
+	if g.mode != (Mode{Rounds: MAX_ROUNDS}) {
+		fmt.Fprintln(output, g.modeTable())
+	}
 
 	// Start the game
-	for ; g.currentRound < MAX_ROUNDS; g.currentRound++ {
-		g.startRound()
-		g.results.unbankAllPlayers()
-		fmt.Println(g.results)
+	for !g.over {
+		g.playRound()
+		fmt.Fprintln(output, g.results)
 	}
 
-	// TODO: Ties?
-	fmt.Printf("Player '%s' won!\n\r", g.results.firstPlayer.Name())
+	fmt.Fprintln(output, g.results.scoreboard())
+	fmt.Fprintln(output, g.results.chart(CHART_HEIGHT))
+	fmt.Fprintln(output, g.Report())
+
+	if len(g.results.teams) > 0 {
+		g.printWinningTeams()
+		return nil
+	}
+
+	winners := g.results.leaders()
+	switch len(winners) {
+	case 0:
+		fmt.Fprintln(output, i18n.T(i18n.MSG_NOBODY_PLAYED))
+	case 1:
+		printMsg(i18n.MSG_PLAYER_WON, winners[0].Name())
+	default:
+		names := make([]string, len(winners))
+		for idx, winner := range winners {
+			names[idx] = fmt.Sprintf("'%s'", winner.Name())
+		}
+		printMsg(i18n.MSG_PLAYERS_TIED, strings.Join(names, ", "))
+	}
 	return nil
 }
 
 /**
- * Starts a round of Bank
+ * Plays the current round of Bank in the terminal
  */
-func (g *Game) startRound() {
-	round := g.rounds[g.currentRound]
+func (g *Game) playRound() {
+	round := &g.rounds[g.currentRound]
 
-	fmt.Printf("\n\r### Starting Round %d of 20 ###\n\r", g.currentRound+1)
+	fmt.Fprint(output, "\n\r")
+	printMsg(i18n.MSG_ROUND_STARTING, g.roundTitle())
 
-	dice, keepRolling := g.roll(&round)
+	dice, keepRolling := g.roll(round)
 	bankedRound := false
 	for keepRolling {
-		fmt.Println()
-		fmt.Println(dice)
-		fmt.Printf("Current Points: %d\n\r", round.points)
-		fmt.Printf("Roll Number: %d\n\r\n\r", len(round.rolls))
+		fmt.Fprintln(output)
+		fmt.Fprintln(output, dice)
+		printMsg(i18n.MSG_CURRENT_POINTS, round.points)
+		printMsg(i18n.MSG_ROLL_NUMBER, len(round.rolls))
+		fmt.Fprint(output, "\n\r")
+
+		printAiAgentBanks(g.askAiAgentsToBank())
 
-		g.askAiAgentsToBank()
-		for keepPrompting := true; keepPrompting; {
+		// Nobody to prompt if only AI Agents are playing
+		for keepPrompting := !g.onlyAI; keepPrompting; {
 			switch prompt() {
 			case PRINT_POINTS:
-				fmt.Println(g.results)
+				fmt.Fprintln(output, g.results)
+			case PRINT_SCOREBOARD:
+				fmt.Fprintln(output, g.results.scoreboard())
+				fmt.Fprintln(output, g.results.chart(CHART_HEIGHT))
 			case PLAYERS_BANK:
+				g.startBankAction()
 				bankingPlayers := getBankingPlayers(g.results.getUnbankedPlayers())
 				for _, player := range bankingPlayers {
-					keepPrompting = !g.results.playerBanks(g.players[player], round.points)
+					// Could have banked with a teammate already
+					if !g.results.playerBanked(g.players[player]) {
+						// The host is prompted until the roll so they can undo the last bank
+						keepPrompting = !g.bank(g.players[player]) || g.host != ""
+					}
 				}
 
 				// This allows the agents and humans to have the
 				// same advantage of banking after someone else banks.
 				if len(bankingPlayers) > 0 {
-					g.askAiAgentsToBank()
+					printAiAgentBanks(g.askAiAgentsToBank())
 				}
+				g.endBankAction()
+
+			case UNDO:
+				host := GetInput(i18n.T(i18n.PROMPT_HOST_NAME)+" "+PROMPT, false)
+				if undo, err := g.Undo(host); err != nil {
+					printMsg(i18n.MSG_CANT_UNDO, err)
+				} else {
+					printMsg(i18n.MSG_UNDONE, undo.Host, undo.banks())
+					fmt.Fprint(output, "\n\r")
+				}
+
+			case JOIN:
+				g.promptJoin()
+
+			case LEAVE:
+				g.promptLeave()
+				keepPrompting = !g.onlyAI
 
 			case ROLL_DICE:
 				keepPrompting = false
@@ -173,16 +311,35 @@ func (g *Game) startRound() {
 			keepRolling = false
 			bankedRound = true
 		} else {
-			dice, keepRolling = g.roll(&round)
+			dice, keepRolling = g.roll(round)
 		}
 	}
 
 	if !bankedRound {
-		fmt.Println()
-		fmt.Println(dice)
-		fmt.Printf("Roll Number: %d\n\r", len(round.rolls))
+		fmt.Fprintln(output)
+		fmt.Fprintln(output, dice)
+		printMsg(i18n.MSG_ROLL_NUMBER, len(round.rolls))
+	}
+	printMsg(i18n.MSG_ROUND_DONE, g.currentRound+1)
+	fmt.Fprint(output, "\n\r")
+
+	knocked := len(g.results.eliminated)
+	g.finishRound(!bankedRound)
+	for _, pn := range g.results.eliminated[knocked:] {
+		printMsg(i18n.MSG_KNOCKED_OUT, pn.Name())
+		fmt.Fprint(output, "\n\r")
+	}
+}
+
+/**
+ * Prints which AI Agents banked
+ *
+ * @param agents AI Agents who banked
+ */
+func printAiAgentBanks(agents []Player) {
+	for _, agent := range agents {
+		printMsg(i18n.MSG_AI_BANKED, agent.Name())
 	}
-	fmt.Printf("Round %d done!\n\r\n\r", g.currentRound+1)
 }
 
 /**
@@ -191,17 +348,157 @@ func (g *Game) startRound() {
  * @note Agents will be asked to bank every time regardless if they have banked or not.
  *       This is only to pass the game data to the AI Agent easily and won't change when
  *       they initially stated they wanted to bank.
+ *
+ * @note Agents decide at the same time from the same snapshot, so none of them know who
+ *       else is banking. The banks are then applied together in the order the players
+ *       were added. If anyone banked, the agents who haven't are asked again so they can
+ *       react, until nobody else banks.
+ *
+ * @return The AI Agents who banked
  */
-func (g *Game) askAiAgentsToBank() {
-	for _, player := range g.players {
-		if player.AiAgent() {
-			response := player.Bank(g)
-			if !g.results.playerBanked(player) && response {
-				g.results.playerBanks(player, g.rounds[g.currentRound].points)
-				fmt.Printf("AI Agent '%s' banked!\n\r", player.Name())
+func (g *Game) askAiAgentsToBank() []Player {
+	agents := make([]Player, 0, len(g.order))
+	for _, player := range g.order {
+		if player.AiAgent() && !g.results.players[player.Name()].eliminated {
+			agents = append(agents, player)
+		}
+	}
+
+	banked := make([]Player, 0)
+	for len(agents) > 0 {
+		decisions := g.decideAll(agents)
+		banks := len(banked)
+
+		unbanked := make([]Player, 0, len(agents))
+		for idx, agent := range agents {
+			if g.results.playerBanked(agent) {
+				continue
+			} else if decisions[idx] {
+				g.bank(agent)
+				banked = append(banked, agent)
+			} else {
+				unbanked = append(unbanked, agent)
 			}
 		}
+
+		// Nothing to react to if nobody new banked
+		if len(banked) == banks {
+			break
+		}
+		agents = unbanked
 	}
+	return banked
+}
+
+/**
+ * Ends the current round and moves on to the next one, or ends
+ * the game if it was the last round
+ *
+ * @param busted True if a 7 ended the round, otherwise all players banked
+ */
+func (g *Game) finishRound(busted bool) {
+	current := &g.rounds[g.currentRound]
+	g.lastBank = nil
+
+	if busted {
+		g.bankSafePlayers()
+	}
+	g.results.endRound(current.points, busted)
+	over, knockedOut := g.modeOver()
+	g.logger.Info("round ended",
+		"round", g.currentRound+1,
+		"rolls", len(current.rolls),
+		"points", current.points,
+		"busted", busted,
+		"knockedOut", knockedOut,
+	)
+	g.notify(func(o Observer) {
+		o.OnRoundEnd(RoundEndEvent{
+			Round:      g.currentRound,
+			Rolls:      append([]Dice(nil), current.rolls...),
+			Points:     current.points,
+			Busted:     busted,
+			Standings:  g.results.standings(),
+			KnockedOut: knockedOut,
+		})
+	})
+	g.results.unbankAllPlayers()
+
+	if !over {
+		g.currentRound++
+		g.rounds = append(g.rounds, round{})
+		g.results.startRound()
+		g.seatJoiners()
+		return
+	}
+
+	g.over = true
+	winners := g.Winners()
+	g.logger.Info("game ended", "rounds", g.currentRound+1, "winners", winners, "faults", len(g.results.faults))
+	g.notify(func(o Observer) {
+		o.OnGameEnd(GameEndEvent{
+			Standings: g.results.standings(),
+			Teams:     g.results.teamStandings(),
+			Winners:   winners,
+			Faults:    g.Faults(),
+		})
+	})
+}
+
+/**
+ * Banks the current round points for a player, and their teammates
+ * if the whole team banks together
+ *
+ * @param player Player who is banking
+ *
+ * @return True if all human players have banked, otherwise False
+ */
+func (g *Game) bank(player Player) bool {
+	allHumansBanked := g.bankPlayer(player)
+	if g.teamRule == TEAM_BANK_TOGETHER {
+		for _, teammate := range g.results.unbankedTeammates(player) {
+			allHumansBanked = g.bankPlayer(teammate)
+		}
+	}
+	return allHumansBanked
+}
+
+/**
+ * Banks the current round points for only the player
+ *
+ * @param player Player who is banking
+ *
+ * @return True if all human players have banked, otherwise False
+ */
+func (g *Game) bankPlayer(player Player) bool {
+	round := &g.rounds[g.currentRound]
+	pts := g.results.players[player.Name()].handicap.apply(round.points)
+	allHumansBanked := g.results.playerBanks(player, pts)
+
+	event := BankEvent{
+		Round:      g.currentRound,
+		RollNumber: len(round.rolls),
+		Player:     player.Name(),
+		AiAgent:    player.AiAgent(),
+		Points:     pts,
+		Total:      g.results.getPlayerData(player).Points,
+	}
+	if g.action != nil {
+		g.action.banks = append(g.action.banks, event)
+	}
+	g.logger.Info("bank",
+		"round", event.Round+1,
+		"roll", event.RollNumber,
+		"player", event.Player,
+		"aiAgent", event.AiAgent,
+		"points", event.Points,
+		"total", event.Total,
+	)
+	g.notify(func(o Observer) {
+		o.OnBank(event)
+	})
+
+	return allHumansBanked
 }
 
 /**
@@ -210,10 +507,24 @@ func (g *Game) askAiAgentsToBank() {
 func (g *Game) roll(r *round) (Dice, bool) {
 	roll := new(Dice).roll(g.r)
 	r.rolls = append(r.rolls, roll)
+	g.lastBank = nil // Banks can't be undone once the dice are rolled
 	newPts, cont := roll.Points(len(r.rolls), r.points)
+	g.logRoll(roll, len(r.rolls), r.points, newPts, cont)
 	if cont {
 		r.points = newPts
+		g.results.recordRoll(newPts)
 	}
+
+	g.notify(func(o Observer) {
+		o.OnRoll(RollEvent{
+			Round:      g.currentRound,
+			RollNumber: len(r.rolls),
+			Dice:       roll,
+			Points:     r.points,
+			Busted:     !cont,
+		})
+	})
+
 	return roll, cont
 }
 
@@ -225,17 +536,30 @@ func (g *Game) roll(r *round) (Dice, bool) {
 //////////////////////////////////////////////
 
 type BankDataSnapshot struct {
-	CurrentRound uint8
-	RoundPoints  uint
-	Roll         Dice // Last Rolled Dice
-	Players      []PlayerDataSnapshot
-	Seed         int64
+	CurrentRound uint8                `json:"currentRound"`
+	RoundPoints  uint                 `json:"roundPoints"`
+	Roll         Dice                 `json:"roll"` // Last Rolled Dice
+	RollNumber   int                  `json:"rollNumber"`
+	Players      []PlayerDataSnapshot `json:"players"`
+	Seed         int64                `json:"seed"`
+	MaxRounds    int                  `json:"maxRounds"`             // Most rounds the game plays
+	TargetScore  uint                 `json:"targetScore,omitempty"` // Points that end the game, if any
+	Teams        []TeamDataSnapshot   `json:"teams,omitempty"`       // Teams in ranking order
 }
 
 type PlayerDataSnapshot struct {
-	Name   string
-	Points uint // Total Points thus far
-	Banked bool // True if banked this round, otherwise false
+	Name    string `json:"name"`
+	Points  uint   `json:"points"`  // Total Points thus far
+	Banked  bool   `json:"banked"`  // True if banked this round, otherwise false
+	AiAgent bool   `json:"aiAgent"` // True if the player is an AI Agent
+	Team    string `json:"team,omitempty"`
+
+	Eliminated bool      `json:"eliminated,omitempty"` // True if knocked out of the game or forfeited
+	Handicap   *Handicap `json:"handicap,omitempty"`
+
+	JoinedRound uint8 `json:"joinedRound,omitempty"` // Round a late joiner entered the game in
+	Forfeited   bool  `json:"forfeited,omitempty"`   // True if the player left the game
+	StandIn     bool  `json:"standIn,omitempty"`     // True if an AI Agent took over the seat of a human who left
 }
 
 /**
@@ -247,20 +571,63 @@ type PlayerDataSnapshot struct {
  * @return Snapshot of game data
  */
 func (g *Game) GetData(requestor Player) BankDataSnapshot {
+	return g.snapshot(requestor.Name())
+}
+
+/**
+ * Gets a snapshot of the game data including every player
+ *
+ * @return Snapshot of game data
+ */
+func (g *Game) State() BankDataSnapshot {
+	return g.snapshot("")
+}
+
+/**
+ * Copies the game data
+ *
+ * @param exclude Name of the player to leave out of the snapshot
+ *
+ * @return Snapshot of game data with the players in ranking order
+ */
+func (g *Game) snapshot(exclude string) BankDataSnapshot {
+	if g.frozen != nil {
+		snapshot := g.frozen.snapshot
+		snapshot.Players = make([]PlayerDataSnapshot, 0, len(g.frozen.snapshot.Players))
+		for _, player := range g.frozen.snapshot.Players {
+			if player.Name != exclude {
+				snapshot.Players = append(snapshot.Players, player)
+			}
+		}
+		return snapshot
+	}
+
 	round := g.rounds[g.currentRound]
-	playerData := make([]PlayerDataSnapshot, len(g.players)-1)
+	playerData := make([]PlayerDataSnapshot, 0, len(g.players))
 
-	for name, player := range g.players {
-		if name != requestor.Name() {
-			playerData = append(playerData, g.results.getPlayerData(player))
+	for _, player := range g.results.standings() {
+		if player.Name != exclude {
+			playerData = append(playerData, player)
 		}
 	}
 
-	return BankDataSnapshot{
+	var roll Dice
+	if len(round.rolls) > 0 {
+		roll = round.rolls[len(round.rolls)-1]
+	}
+
+	snapshot := BankDataSnapshot{
 		CurrentRound: g.currentRound,
 		RoundPoints:  round.points,
-		Roll:         round.rolls[len(round.rolls)-1],
+		Roll:         roll,
+		RollNumber:   len(round.rolls),
 		Players:      playerData,
 		Seed:         g.seed,
+		MaxRounds:    g.mode.Rounds,
+		TargetScore:  g.mode.TargetScore,
+	}
+	if len(g.results.teams) > 0 {
+		snapshot.Teams = g.results.teamStandings()
 	}
+	return snapshot
 }
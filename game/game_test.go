@@ -0,0 +1,196 @@
+package game
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Sparhawk96/bank-ais/i18n"
+)
+
+var update = flag.Bool("update", false, "update the golden files in testdata")
+
+/**
+ * AI Agent that banks once the round points reach a threshold
+ */
+type thresholdAgent struct {
+	name      string
+	threshold uint
+}
+
+func (a thresholdAgent) Name() string {
+	return a.name
+}
+
+func (a thresholdAgent) Bank(g *Game) bool {
+	return a.threshold <= g.GetData(a).RoundPoints
+}
+
+func (a thresholdAgent) AiAgent() bool {
+	return true
+}
+
+/**
+ * Plays a full game while discarding what is printed
+ *
+ * @param t Test the game is played for
+ * @param g Game to play
+ */
+func playQuietly(t *testing.T, g *Game) {
+	t.Helper()
+
+	SetOutput(io.Discard)
+	defer SetOutput(os.Stdout)
+
+	if err := g.StartGame(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+/**
+ * Summarizes every round and the final results of a game
+ *
+ * @param g Game that has been played
+ *
+ * @return The summary
+ */
+func summarize(g *Game) string {
+	buf := bytes.NewBufferString("")
+	for num, round := range g.rounds {
+		fmt.Fprintf(buf, "Round %d: %d points\n", num+1, round.points)
+		for _, dice := range round.rolls {
+			fmt.Fprintf(buf, "  %d %d\n", dice[0], dice[1])
+		}
+	}
+	fmt.Fprintln(buf, g.results)
+	return buf.String()
+}
+
+/**
+ * Compares a value to the golden file, or updates it with -update
+ *
+ * @param t Test doing the comparison
+ * @param name Name of the golden file in testdata
+ * @param actual Value to compare
+ */
+func checkGolden(t *testing.T, name string, actual string) {
+	t.Helper()
+
+	path := filepath.Join("testdata", name+".golden")
+	if *update {
+		if err := os.WriteFile(path, []byte(actual), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	expected, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actual != string(expected) {
+		t.Errorf("%s doesn't match the golden file, rerun with -update if the change is intended\n got:\n%s\nexpected:\n%s",
+			path, actual, expected)
+	}
+}
+
+func TestGoldenGames(t *testing.T) {
+	for _, seed := range []int64{1, 42, 2024} {
+		t.Run(fmt.Sprintf("seed_%d", seed), func(t *testing.T) {
+			g := NewGame()
+			if err := g.SetSeed(seed); err != nil {
+				t.Fatal(err)
+			}
+			if err := g.AddPlayer(thresholdAgent{"Steady", 150}); err != nil {
+				t.Fatal(err)
+			}
+
+			playQuietly(t, g)
+			checkGolden(t, fmt.Sprintf("game_seed_%d", seed), summarize(g))
+		})
+	}
+}
+
+func TestGoldenGameManyAgents(t *testing.T) {
+	g := NewGame()
+	g.SetSeed(96)
+	for _, agent := range []thresholdAgent{{"Timid", 40}, {"Steady", 150}, {"Greedy", 400}, {"Copycat", 150}} {
+		if err := g.AddPlayer(agent); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	playQuietly(t, g)
+	checkGolden(t, "game_many_agents", summarize(g))
+}
+
+func TestSameSeedSameGame(t *testing.T) {
+	play := func() string {
+		g := NewGame()
+		g.SetSeed(7)
+		g.AddPlayer(thresholdAgent{"Steady", 200})
+		playQuietly(t, g)
+		return summarize(g)
+	}
+
+	if first, second := play(), play(); first != second {
+		t.Errorf("games with the same seed differ:\n%s\n%s", first, second)
+	}
+}
+
+func TestSetSeedAfterStart(t *testing.T) {
+	g := NewGame()
+	g.AddPlayer(thresholdAgent{"Steady", 100})
+	playQuietly(t, g)
+
+	if err := g.SetSeed(1); err == nil {
+		t.Error("expected an error setting the seed after the game started")
+	}
+	if err := g.AddPlayer(thresholdAgent{"Late", 100}); err == nil {
+		t.Error("expected an error adding a player after the game started")
+	}
+}
+
+func TestInputDoneRollsDice(t *testing.T) {
+	km := i18n.Keymap{i18n.CMD_ROLL: {"", "go"}, i18n.CMD_POINTS: {"pts"}}
+	if err := i18n.UseKeymap(km); err != nil {
+		t.Fatal(err)
+	}
+	defer i18n.UseKeymap(nil)
+
+	var out bytes.Buffer
+	SetOutput(&out)
+	defer SetOutput(os.Stdout)
+	defer SetInput(os.Stdin, false)
+
+	// The last line has no newline, so it is read along with the end of the input
+	SetInput(strings.NewReader("pts\nwat"), false)
+	if request := prompt(); request != PRINT_POINTS {
+		t.Fatalf("expected the keymap's points command, got %v", request)
+	}
+	for range 3 {
+		if request := prompt(); request != ROLL_DICE {
+			t.Fatalf("expected to roll once the input is done, got %v", request)
+		}
+	}
+	if strings.Contains(out.String(), i18n.T(i18n.PROMPT_INVALID_INPUT, "")) {
+		t.Errorf("expected the end of the input to not be invalid:\n%s", out.String())
+	}
+
+	// A game the script runs out on still finishes
+	g := NewGame()
+	g.SetSeed(42)
+	g.AddPlayer(NewHumanPlayer("Ann"))
+	g.AddPlayer(thresholdAgent{"Steady", 50})
+	SetInput(strings.NewReader("go\npts\n"), false)
+	if err := g.StartGame(); err != nil {
+		t.Fatal(err)
+	}
+	if !g.Over() {
+		t.Error("expected the game to finish once the input was done")
+	}
+}
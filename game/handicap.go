@@ -0,0 +1,115 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/Sparhawk96/bank-ais/i18n"
+)
+
+/**
+ * Evens out a game between players of different skill. The zero value is no handicap.
+ */
+type Handicap struct {
+	StartingPoints uint    `json:"startingPoints,omitempty"` // Points the player starts the game with
+	Multiplier     float64 `json:"multiplier,omitempty"`     // Scales the points the player banks, 0 is the same as 1
+
+	// Rolls after the SAFE_ROLLS where a 7 doesn't cost the player the round's
+	// points. The player keeps the points as if they banked before the 7.
+	ExtraSafeRolls int `json:"extraSafeRolls,omitempty"`
+}
+
+/**
+ * Checks the handicap can be played
+ *
+ * @return An error if the handicap isn't valid
+ */
+func (h Handicap) validate() error {
+	if h.Multiplier < 0 || math.IsNaN(h.Multiplier) || math.IsInf(h.Multiplier, 0) {
+		return fmt.Errorf("invalid point multiplier: %v", h.Multiplier)
+	} else if h.ExtraSafeRolls < 0 {
+		return errors.New("extra safe rolls can't be negative")
+	}
+	return nil
+}
+
+/**
+ * Dictates if the handicap changes anything
+ *
+ * @return True if the player isn't handicapped, otherwise false
+ */
+func (h Handicap) none() bool {
+	return h.StartingPoints == 0 && (h.Multiplier == 0 || h.Multiplier == 1) && h.ExtraSafeRolls == 0
+}
+
+/**
+ * Scales points by the multiplier
+ *
+ * @param pts Points to scale
+ *
+ * @return Points the player is given, rounded to the nearest point
+ */
+func (h Handicap) apply(pts uint) uint {
+	if h.Multiplier == 0 {
+		return pts
+	}
+	return uint(math.Round(float64(pts) * h.Multiplier))
+}
+
+/**
+ * Dictates if a 7 on a roll doesn't cost the player the round's points
+ *
+ * @param rollNum Roll number the 7 was rolled on
+ *
+ * @return True if the roll is one of the player's extra safe rolls
+ */
+func (h Handicap) safe(rollNum int) bool {
+	return rollNum <= SAFE_ROLLS+h.ExtraSafeRolls
+}
+
+func (h Handicap) String() string {
+	parts := make([]string, 0, 3)
+	if h.StartingPoints > 0 {
+		parts = append(parts, i18n.T(i18n.CELL_HANDICAP_PTS, h.StartingPoints))
+	}
+	if h.Multiplier != 0 && h.Multiplier != 1 {
+		parts = append(parts, fmt.Sprintf("×%g", h.Multiplier))
+	}
+	if h.ExtraSafeRolls == 1 {
+		parts = append(parts, i18n.T(i18n.CELL_SAFE_ROLL))
+	} else if h.ExtraSafeRolls > 1 {
+		parts = append(parts, i18n.T(i18n.CELL_SAFE_ROLLS, h.ExtraSafeRolls))
+	}
+	return strings.Join(parts, ", ")
+}
+
+/**
+ * Gives the players whose extra safe rolls cover the 7 that ended the round
+ * the round's points, as if they banked before the 7
+ *
+ * @note The round must have ended with a 7
+ */
+func (g *Game) bankSafePlayers() {
+	round := &g.rounds[g.currentRound]
+	for _, player := range g.results.getUnbankedPlayers() {
+		if g.results.players[player.Name()].handicap.safe(len(round.rolls)) {
+			g.bankPlayer(player)
+		}
+	}
+}
+
+/**
+ * Dictates if any player is handicapped
+ *
+ * @return True if a player is handicapped, otherwise false
+ */
+func (r *results) anyHandicaps() bool {
+	for _, pn := range r.players {
+		if !pn.handicap.none() {
+			return true
+		}
+	}
+	return false
+}
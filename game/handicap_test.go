@@ -0,0 +1,93 @@
+package game
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHandicapStartingPointsAndMultiplier(t *testing.T) {
+	g := NewGame()
+	g.AddPlayer(NewHumanPlayer("Ann"), Handicap{StartingPoints: 100})
+	g.AddPlayer(NewHumanPlayer("Bob"), Handicap{Multiplier: 1.5})
+	g.AddPlayer(NewHumanPlayer("Cat"))
+	g.Begin()
+
+	if state := g.State(); state.Players[0].Name != "Ann" || state.Players[0].Points != 100 {
+		t.Fatalf("expected Ann to start in the lead with 100 points: %+v", state.Players)
+	}
+
+	g.rounds[0].points = 75
+	g.rounds[0].rolls = make([]Dice, SAFE_ROLLS+1)
+	g.BankPlayer("Bob")
+	g.BankPlayer("Cat")
+
+	points := make(map[string]uint)
+	for _, player := range g.State().Players {
+		points[player.Name] = player.Points
+	}
+	if points["Ann"] != 100 || points["Bob"] != 113 || points["Cat"] != 75 {
+		t.Errorf("unexpected points: %v", points)
+	}
+
+	// The scoreboard adds up to the totals with the starting points
+	if out := g.results.scoreboard().String(); !strings.Contains(out, "Start") {
+		t.Errorf("expected a row of starting points:\n%s", out)
+	}
+}
+
+func TestHandicapExtraSafeRolls(t *testing.T) {
+	g := NewGame()
+	g.AddPlayer(NewHumanPlayer("Ann"), Handicap{ExtraSafeRolls: 2})
+	g.AddPlayer(NewHumanPlayer("Bob"), Handicap{ExtraSafeRolls: 1})
+	g.AddPlayer(NewHumanPlayer("Cat"))
+	g.Begin()
+
+	// A 7 on the fifth roll is safe for Ann but not Bob or Cat
+	g.rounds[0].points = 200
+	g.rounds[0].rolls = make([]Dice, SAFE_ROLLS+2)
+	g.finishRound(true)
+
+	banks := g.results.history[0].banks
+	if len(banks) != 1 || banks["Ann"] != 200 {
+		t.Errorf("expected only Ann to keep the pot, got %v", banks)
+	}
+	if g.results.players["Ann"].banked {
+		t.Error("expected Ann to be unbanked for the next round")
+	}
+}
+
+func TestHandicapErrors(t *testing.T) {
+	g := NewGame()
+	for _, handicap := range []Handicap{{Multiplier: -1}, {ExtraSafeRolls: -1}} {
+		if err := g.AddPlayer(NewHumanPlayer("Ann"), handicap); err == nil {
+			t.Errorf("expected an error for %+v", handicap)
+		}
+	}
+	if err := g.AddPlayer(NewHumanPlayer("Ann"), Handicap{}, Handicap{}); err == nil {
+		t.Error("expected an error for two handicaps")
+	}
+	if err := g.AddPlayer(NewHumanPlayer("Ann"), Handicap{Multiplier: 1}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestHandicapDisplay(t *testing.T) {
+	handicap := Handicap{StartingPoints: 100, Multiplier: 1.5, ExtraSafeRolls: 2}
+	if got := handicap.String(); got != "+100 pts, ×1.5, +2 safe rolls" {
+		t.Errorf("unexpected handicap: %s", got)
+	}
+
+	g := NewGame()
+	g.AddPlayer(NewHumanPlayer("Ann"))
+	if strings.Contains(g.results.String(), "Handicap") {
+		t.Error("expected no handicap column without handicaps")
+	}
+
+	g.AddPlayer(NewHumanPlayer("Bob"), handicap)
+	if out := g.results.String(); !strings.Contains(out, "Handicap") || !strings.Contains(out, "+2 safe rolls") {
+		t.Errorf("expected a handicap column:\n%s", out)
+	}
+	if data := g.results.getPlayerData(g.players["Bob"]); data.Handicap == nil || *data.Handicap != handicap {
+		t.Errorf("expected the handicap in the snapshot: %+v", data)
+	}
+}
@@ -0,0 +1,137 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+)
+
+/**
+ * Handler that drops every record, used until a game is given a logger
+ */
+type discardHandler struct{}
+
+func (discardHandler) Enabled(context.Context, slog.Level) bool  { return false }
+func (discardHandler) Handle(context.Context, slog.Record) error { return nil }
+func (h discardHandler) WithAttrs([]slog.Attr) slog.Handler      { return h }
+func (h discardHandler) WithGroup(string) slog.Handler           { return h }
+
+var discardLogger = slog.New(discardHandler{})
+
+/**
+ * Sets the logger that traces the game.
+ *
+ * Rolls, the points they're worth, AI Agent decisions and ranking changes are
+ * logged at debug level. Game starts and ends, rounds and banks at info level,
+ * and faults at warn level.
+ *
+ * @param logger Logger to trace the game with, nil to stop tracing
+ *
+ * @return An error if the game has started
+ */
+func (g *Game) SetLogger(logger *slog.Logger) error {
+	if g.started {
+		return errors.New(GAME_HAS_STARTED_ERR_MSG)
+	} else if logger == nil {
+		logger = discardLogger
+	}
+
+	g.logger = logger
+	g.results.setLogger(logger)
+	return nil
+}
+
+/**
+ * Sets the logger of the rankings, so players moving up or down are traced
+ *
+ * @param logger Logger of the game
+ */
+func (r *results) setLogger(logger *slog.Logger) {
+	r.logger = logger
+	if r.ranking != nil {
+		r.ranking.logger = r.rankingLogger("players")
+	}
+	if r.teamRanking != nil {
+		r.teamRanking.logger = r.rankingLogger("teams")
+	}
+}
+
+/**
+ * Gets the logger of a ranking
+ *
+ * @param kind What is ranked, such as players or teams
+ *
+ * @return Logger of the ranking, nil if the game isn't traced
+ */
+func (r *results) rankingLogger(kind string) *slog.Logger {
+	if r.logger == nil {
+		return nil
+	}
+	return r.logger.With("ranking", kind)
+}
+
+/**
+ * Describes which rule of Dice.Points scored a roll
+ *
+ * @param d Dice that were rolled
+ * @param rollNum Roll number in round
+ *
+ * @return Name of the rule
+ */
+func pointsRule(d Dice, rollNum int) string {
+	switch num := d[0] + d[1]; {
+	case rollNum <= SAFE_ROLLS && num == 7:
+		return "safe seven"
+	case rollNum <= SAFE_ROLLS:
+		return "safe"
+	case num == 7:
+		return "bust"
+	case d[0] == d[1]:
+		return "doubles"
+	default:
+		return "sum"
+	}
+}
+
+/**
+ * Traces a roll and how its points were computed
+ *
+ * @param dice Dice that were rolled
+ * @param rollNum Roll number in round
+ * @param before Round points before the roll
+ * @param after Round points computed by Dice.Points
+ * @param keepRolling True if the round goes on
+ */
+func (g *Game) logRoll(dice Dice, rollNum int, before uint, after uint, keepRolling bool) {
+	g.logger.Debug("roll",
+		"round", g.currentRound+1,
+		"roll", rollNum,
+		"dice", []Die{dice[0], dice[1]},
+		slog.Group("points",
+			"rule", pointsRule(dice, rollNum),
+			"before", before,
+			"after", after,
+			"keepRolling", keepRolling,
+		),
+	)
+}
+
+/**
+ * Traces an AI Agent deciding if it will bank
+ *
+ * @param player AI Agent who decided
+ * @param d Decision of the agent
+ */
+func (g *Game) logDecision(player Player, d decision) {
+	attrs := []any{
+		"round", g.currentRound + 1,
+		"roll", len(g.rounds[g.currentRound].rolls),
+		"player", player.Name(),
+		"bank", d.bank,
+		"latency", d.latency,
+	}
+	if d.err != nil {
+		attrs = append(attrs, "error", d.err.Error())
+	}
+	g.logger.Debug("agent decision", attrs...)
+}
@@ -0,0 +1,110 @@
+package game
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"testing"
+)
+
+/**
+ * Plays a seeded game between AI Agents while tracing it
+ *
+ * @param t Test the game is played for
+ * @param level Lowest level traced
+ *
+ * @return Every record traced, decoded from JSON
+ */
+func traceGame(t *testing.T, level slog.Level) []map[string]any {
+	t.Helper()
+
+	var buf bytes.Buffer
+	g := NewGame()
+	g.SetSeed(42)
+	if err := g.SetLogger(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: level}))); err != nil {
+		t.Fatal(err)
+	}
+	g.AddPlayer(thresholdAgent{"Greedy", 400})
+	g.AddPlayer(thresholdAgent{"Steady", 150}) // Passes Greedy the first time they bank
+	playQuietly(t, g)
+
+	records := make([]map[string]any, 0)
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		var record map[string]any
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			t.Fatalf("invalid record %q: %v", scanner.Text(), err)
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestLoggerTracesTheGame(t *testing.T) {
+	counts := make(map[string]int)
+	for _, record := range traceGame(t, slog.LevelDebug) {
+		msg := record["msg"].(string)
+		counts[msg]++
+
+		switch msg {
+		case "roll":
+			points, ok := record["points"].(map[string]any)
+			if !ok || points["rule"] == nil || points["after"] == nil {
+				t.Errorf("expected the points computation: %v", record)
+			}
+		case "agent decision":
+			if _, ok := record["latency"]; !ok {
+				t.Errorf("expected the latency of the decision: %v", record)
+			}
+		case "ranking changed":
+			if record["ranking"] != "players" || record["from"] == record["to"] {
+				t.Errorf("unexpected ranking change: %v", record)
+			}
+		}
+	}
+
+	for _, msg := range []string{"game started", "roll", "agent decision", "bank", "ranking changed", "round ended"} {
+		if counts[msg] == 0 {
+			t.Errorf("expected %q to be traced, got %v", msg, counts)
+		}
+	}
+	if counts["game started"] != 1 || counts["game ended"] != 1 || counts["round ended"] != MAX_ROUNDS {
+		t.Errorf("unexpected counts: %v", counts)
+	}
+}
+
+func TestLoggerLevel(t *testing.T) {
+	for _, record := range traceGame(t, slog.LevelInfo) {
+		if record["level"] == slog.LevelDebug.String() {
+			t.Fatalf("expected no debug records: %v", record)
+		}
+	}
+}
+
+func TestSetLoggerAfterStart(t *testing.T) {
+	g := NewGame()
+	g.AddPlayer(thresholdAgent{"Steady", 150})
+	g.Begin()
+	if err := g.SetLogger(slog.Default()); err == nil {
+		t.Error("expected an error once the game started")
+	}
+}
+
+func TestPointsRule(t *testing.T) {
+	for _, tc := range []struct {
+		dice    Dice
+		rollNum int
+		want    string
+	}{
+		{Dice{3, 4}, 1, "safe seven"},
+		{Dice{2, 2}, SAFE_ROLLS, "safe"},
+		{Dice{6, 1}, SAFE_ROLLS + 1, "bust"},
+		{Dice{5, 5}, SAFE_ROLLS + 1, "doubles"},
+		{Dice{5, 6}, SAFE_ROLLS + 1, "sum"},
+	} {
+		if got := pointsRule(tc.dice, tc.rollNum); got != tc.want {
+			t.Errorf("%v on roll %d: got %q, expected %q", tc.dice, tc.rollNum, got, tc.want)
+		}
+	}
+}
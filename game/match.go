@@ -0,0 +1,168 @@
+package game
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/Sparhawk96/bank-ais/i18n"
+	"github.com/Sparhawk96/bank-ais/table"
+)
+
+/**
+ * Best of N match played across multiple games. Every player tied for the
+ * win of a game is given the win.
+ */
+type Match struct {
+	games  int
+	played int
+	order  []string // Players in the order they first played
+	wins   map[string]int
+	points map[string]uint
+}
+
+type MatchStanding struct {
+	Name   string `json:"name"`
+	Wins   int    `json:"wins"`
+	Points uint   `json:"points"` // Total points across every game
+}
+
+/**
+ * Creates a best of N match
+ *
+ * @param games Most games played
+ *
+ * @return The match, or an error if there isn't at least one game
+ */
+func NewMatch(games int) (*Match, error) {
+	if games < 1 {
+		return nil, errors.New("a match needs at least one game")
+	}
+	return &Match{
+		games:  games,
+		wins:   make(map[string]int),
+		points: make(map[string]uint),
+	}, nil
+}
+
+/**
+ * Records the results of a finished game
+ *
+ * @param g Game that's over
+ *
+ * @return An error if the game isn't over or the match is
+ */
+func (m *Match) Record(g *Game) error {
+	if !g.Over() {
+		return errors.New("game isn't over")
+	} else if m.Over() {
+		return errors.New("match is over")
+	}
+
+	m.played++
+	for _, player := range g.State().Players {
+		if _, has := m.points[player.Name]; !has {
+			m.order = append(m.order, player.Name)
+		}
+		m.points[player.Name] += player.Points
+	}
+	for _, winner := range g.results.leaders() {
+		m.wins[winner.Name()]++
+	}
+	return nil
+}
+
+/**
+ * Dictates if the match is over, once every game is played or
+ * someone has won more than half of the games
+ *
+ * @return True if the match is over, otherwise false
+ */
+func (m *Match) Over() bool {
+	if m.played >= m.games {
+		return true
+	}
+	for _, wins := range m.wins {
+		if wins*2 > m.games {
+			return true
+		}
+	}
+	return false
+}
+
+/**
+ * Gets the number of games played
+ *
+ * @return Games played thus far
+ */
+func (m *Match) Played() int {
+	return m.played
+}
+
+/**
+ * Gets the standings of the match
+ *
+ * @return Players with the most wins first, then the most points, ties in the
+ *         order they first played
+ */
+func (m *Match) Standings() []MatchStanding {
+	standings := make([]MatchStanding, 0, len(m.order))
+	for _, name := range m.order {
+		standings = append(standings, MatchStanding{Name: name, Wins: m.wins[name], Points: m.points[name]})
+	}
+	sort.SliceStable(standings, func(i, j int) bool {
+		if standings[i].Wins != standings[j].Wins {
+			return standings[i].Wins > standings[j].Wins
+		}
+		return standings[i].Points > standings[j].Points
+	})
+	return standings
+}
+
+/**
+ * Gets who is winning the match
+ *
+ * @return Players tied for the most wins and points
+ */
+func (m *Match) Winners() []string {
+	winners := make([]string, 0)
+	standings := m.Standings()
+	for _, standing := range standings {
+		if standing.Wins != standings[0].Wins || standing.Points != standings[0].Points {
+			break
+		}
+		winners = append(winners, standing.Name)
+	}
+	return winners
+}
+
+func (m *Match) String() string {
+	playerHdr := i18n.T(i18n.HDR_PLAYER)
+	winsHdr := i18n.T(i18n.HDR_WINS)
+	pointsHdr := i18n.T(i18n.HDR_TOTAL_POINTS)
+
+	t := new(table.Table)
+	t.Title = i18n.T(i18n.TITLE_MATCH, m.games, m.played)
+	t.HeaderHighlight = table.Highlight{Bold: true}
+	t.CreateColumn(playerHdr, table.LEFT, 0)
+	t.CreateColumn(winsHdr, table.RIGHT, 0)
+	t.CreateColumn(pointsHdr, table.RIGHT, 0)
+
+	leaders := make(map[string]bool)
+	for _, winner := range m.Winners() {
+		leaders[winner] = true
+	}
+
+	for _, standing := range m.Standings() {
+		data := map[string]any{
+			playerHdr: standing.Name,
+			winsHdr:   standing.Wins,
+			pointsHdr: standing.Points,
+		}
+		if leaders[standing.Name] {
+			t.AddHighlightedEntry(data, table.Highlight{Bold: true})
+		} else {
+			t.AddEntry(data)
+		}
+	}
+	return t.String()
+}
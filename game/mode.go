@@ -0,0 +1,199 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/Sparhawk96/bank-ais/i18n"
+	"github.com/Sparhawk96/bank-ais/table"
+)
+
+// Most rounds a target score game plays if nobody reaches the target
+const TARGET_MAX_ROUNDS = 100
+
+/**
+ * How a game ends. The zero value is the classic game of MAX_ROUNDS rounds.
+ */
+type Mode struct {
+	Rounds int // Rounds played, or the most rounds played with a target score or eliminations
+
+	// The game ends after the round someone reaches the target, 0 for no target
+	TargetScore uint
+
+	// The lowest scorer is knocked out every this many rounds until one player
+	// is left, 0 for no eliminations. Ties knock out the player added last.
+	EliminateEvery int
+}
+
+/**
+ * Sets how the game ends
+ *
+ * @param mode Mode of the game
+ *
+ * @return An error if the game has started or the mode isn't valid
+ */
+func (g *Game) SetMode(mode Mode) error {
+	if g.started {
+		return errors.New(GAME_HAS_STARTED_ERR_MSG)
+	} else if mode.Rounds < 0 || mode.EliminateEvery < 0 {
+		return errors.New("rounds can't be negative")
+	} else if mode.Rounds > math.MaxUint8 {
+		return fmt.Errorf("can't play more than %d rounds", math.MaxUint8)
+	}
+
+	if mode.Rounds == 0 {
+		mode.Rounds = MAX_ROUNDS
+		if mode.TargetScore > 0 || mode.EliminateEvery > 0 {
+			mode.Rounds = TARGET_MAX_ROUNDS
+		}
+	}
+	g.mode = mode
+	return nil
+}
+
+/**
+ * Gets how the game ends
+ *
+ * @return Mode of the game
+ */
+func (g *Game) Mode() Mode {
+	return g.mode
+}
+
+/**
+ * Checks the mode can be played with the players
+ *
+ * @return An error if the mode can't be played
+ */
+func (g *Game) checkMode() error {
+	if g.mode.EliminateEvery > 0 && len(g.results.teams) > 0 {
+		return errors.New("eliminations can't be played with teams")
+	}
+	return nil
+}
+
+/**
+ * Ends the round for the mode, knocking out the lowest scorer if it's time to
+ *
+ * @return True if the game is over, and the name of who was knocked out if anyone
+ */
+func (g *Game) modeOver() (bool, string) {
+	played := int(g.currentRound) + 1
+	if g.results.survivors() == 0 {
+		return true, "" // Everyone forfeited
+	}
+
+	knockedOut := ""
+	if g.mode.EliminateEvery > 0 && played%g.mode.EliminateEvery == 0 {
+		if last := g.results.lastPlace(); last != nil && g.results.survivors() > 1 {
+			g.results.eliminate(last, g.currentRound)
+			g.onlyAI = g.results.humanPlayers == 0
+			knockedOut = last.Name()
+		}
+		if g.results.survivors() <= 1 {
+			return true, knockedOut
+		}
+	}
+
+	if g.mode.TargetScore > 0 && g.results.leadingPoints() >= g.mode.TargetScore {
+		return true, knockedOut
+	}
+	return played >= g.mode.Rounds, knockedOut
+}
+
+/**
+ * Gets the title of a round for the terminal
+ *
+ * @return Round number and how the game ends
+ */
+func (g *Game) roundTitle() string {
+	switch {
+	case g.mode.TargetScore > 0:
+		return i18n.T(i18n.MSG_ROUND_TARGET, g.currentRound+1, g.mode.TargetScore)
+	case g.mode.EliminateEvery > 0:
+		next := g.mode.EliminateEvery - int(g.currentRound)%g.mode.EliminateEvery
+		return i18n.T(i18n.MSG_ROUND_ELIMINATION, g.currentRound+1, next)
+	}
+	return i18n.T(i18n.MSG_ROUND_OF, g.currentRound+1, g.mode.Rounds)
+}
+
+/**
+ * Knocks a player out of the game
+ *
+ * @param pn Player to knock out
+ * @param round Round they were knocked out in
+ */
+func (r *results) eliminate(pn *playerNode, round uint8) {
+	pn.eliminated = true
+	pn.eliminatedIn = round
+	r.eliminated = append(r.eliminated, pn)
+	if !pn.AiAgent() {
+		r.humanPlayers--
+		if pn.banked {
+			r.bankedHumanPlayers--
+		}
+	}
+}
+
+/**
+ * Gets the player in last place who is still playing
+ *
+ * @return The last player, or nil if nobody is playing
+ */
+func (r *results) lastPlace() *playerNode {
+	var last *playerNode
+	for _, pn := range r.rankedPlayers() {
+		if !pn.eliminated {
+			last = pn
+		}
+	}
+	return last
+}
+
+/**
+ * Gets the number of players who haven't been knocked out
+ *
+ * @return Players still playing
+ */
+func (r *results) survivors() int {
+	return len(r.players) - len(r.eliminated)
+}
+
+/**
+ * Gets the points of whoever is in first, a team's total when playing with teams
+ *
+ * @return Most points
+ */
+func (r *results) leadingPoints() uint {
+	if teams := r.rankedTeams(); len(teams) > 0 {
+		return teams[0].pts
+	} else if ranked := r.rankedPlayers(); len(ranked) > 0 {
+		return ranked[0].pts
+	}
+	return 0
+}
+
+/**
+ * Creates a table of the game's mode for the terminal
+ *
+ * @return Table of how the game ends
+ */
+func (g *Game) modeTable() *table.Table {
+	ruleHdr := i18n.T(i18n.HDR_RULE)
+	valueHdr := i18n.T(i18n.HDR_VALUE)
+
+	t := new(table.Table)
+	t.Title = i18n.T(i18n.TITLE_GAME_MODE)
+	t.CreateColumn(ruleHdr, table.LEFT, 0)
+	t.CreateColumn(valueHdr, table.LEFT, 0)
+
+	if g.mode.TargetScore > 0 {
+		t.AddEntry(map[string]any{ruleHdr: i18n.T(i18n.CELL_FIRST_TO), valueHdr: g.mode.TargetScore})
+	}
+	if g.mode.EliminateEvery > 0 {
+		t.AddEntry(map[string]any{ruleHdr: i18n.T(i18n.CELL_ELIMINATION), valueHdr: i18n.T(i18n.CELL_EVERY_ROUNDS, g.mode.EliminateEvery)})
+	}
+	t.AddEntry(map[string]any{ruleHdr: i18n.T(i18n.CELL_MOST_ROUNDS), valueHdr: g.mode.Rounds})
+	return t
+}
@@ -0,0 +1,205 @@
+package game
+
+import "testing"
+
+/**
+ * Plays a game of agents in a mode, keeping every round end
+ */
+func playMode(t *testing.T, mode Mode, agents ...Player) (*Game, []RoundEndEvent) {
+	t.Helper()
+
+	g := NewGame()
+	g.SetSeed(42)
+	if err := g.SetMode(mode); err != nil {
+		t.Fatal(err)
+	}
+	for _, agent := range agents {
+		g.AddPlayer(agent)
+	}
+
+	observer := new(recordingObserver)
+	g.AddObserver(observer)
+	playQuietly(t, g)
+
+	roundEnds := make([]RoundEndEvent, 0)
+	for _, event := range observer.events {
+		if roundEnd, isRoundEnd := event.(RoundEndEvent); isRoundEnd {
+			roundEnds = append(roundEnds, roundEnd)
+		}
+	}
+	return g, roundEnds
+}
+
+func TestModeRounds(t *testing.T) {
+	_, roundEnds := playMode(t, Mode{}, thresholdAgent{"Timid", 50})
+	if len(roundEnds) != MAX_ROUNDS {
+		t.Errorf("expected %d rounds by default, played %d", MAX_ROUNDS, len(roundEnds))
+	}
+
+	g, roundEnds := playMode(t, Mode{Rounds: 5}, thresholdAgent{"Timid", 50})
+	if len(roundEnds) != 5 || g.State().MaxRounds != 5 {
+		t.Errorf("expected 5 rounds, played %d", len(roundEnds))
+	}
+}
+
+func TestModeTargetScore(t *testing.T) {
+	target := uint(400)
+	g, roundEnds := playMode(t, Mode{TargetScore: target},
+		thresholdAgent{"Timid", 50}, thresholdAgent{"Greedy", 150})
+
+	for idx, roundEnd := range roundEnds {
+		reached := roundEnd.Standings[0].Points >= target
+		if last := idx == len(roundEnds)-1; reached != last {
+			t.Fatalf("round %d: leader has %d points, last round is %d", idx+1, roundEnd.Standings[0].Points, len(roundEnds))
+		}
+	}
+	if len(roundEnds) == MAX_ROUNDS {
+		t.Errorf("expected the target to end the game early")
+	}
+
+	leaders := g.results.leaders()
+	if len(leaders) != 1 || leaders[0].Name() != roundEnds[len(roundEnds)-1].Standings[0].Name {
+		t.Errorf("unexpected winners: %v", leaders)
+	}
+}
+
+func TestModeElimination(t *testing.T) {
+	g, roundEnds := playMode(t, Mode{EliminateEvery: 2},
+		thresholdAgent{"Ann", 20}, thresholdAgent{"Bob", 60}, thresholdAgent{"Cat", 120}, thresholdAgent{"Dan", 200})
+
+	if len(roundEnds) != 6 {
+		t.Fatalf("expected 3 eliminations over 6 rounds, played %d", len(roundEnds))
+	}
+
+	knockedOut := make([]string, 0)
+	for idx, roundEnd := range roundEnds {
+		if (idx+1)%2 == 0 && roundEnd.KnockedOut == "" {
+			t.Errorf("expected a knock out after round %d", idx+1)
+		}
+		if roundEnd.KnockedOut != "" {
+			knockedOut = append(knockedOut, roundEnd.KnockedOut)
+		}
+	}
+
+	// Survivor first, then whoever lasted the longest
+	standings := g.State().Players
+	for idx, out := range knockedOut {
+		player := standings[len(standings)-1-idx]
+		if player.Name != out || !player.Eliminated {
+			t.Errorf("expected %s in place %d, got %+v", out, len(standings)-idx, player)
+		}
+	}
+	if standings[0].Eliminated {
+		t.Errorf("expected the survivor first, got %+v", standings[0])
+	}
+
+	leaders := g.results.leaders()
+	if len(leaders) != 1 || leaders[0].Name() != standings[0].Name {
+		t.Errorf("expected only the survivor to win, got %v", leaders)
+	}
+
+	// Knocked out players stop banking
+	for _, out := range knockedOut {
+		for round := int(g.results.players[out].eliminatedIn) + 1; round < len(g.results.history); round++ {
+			if _, banked := g.results.history[round].banks[out]; banked {
+				t.Errorf("%s banked in round %d after being knocked out", out, round+1)
+			}
+		}
+	}
+}
+
+func TestModeKnockedOutHumanCantBank(t *testing.T) {
+	g := NewGame()
+	g.SetSeed(42)
+	g.SetMode(Mode{EliminateEvery: 1})
+	g.AddPlayer(thresholdAgent{"Timid", 1})
+	g.AddPlayer(NewHumanPlayer("Ann"))
+	g.Begin()
+
+	// Ann never banks so is knocked out after the first round, leaving one player
+	for !g.Over() {
+		if _, err := g.Roll(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if g.State().CurrentRound != 0 {
+		t.Errorf("expected the game to end after the first round")
+	}
+	if err := g.BankPlayer("Ann"); err == nil {
+		t.Error("expected an error banking for a knocked out player")
+	}
+}
+
+func TestSetModeErrors(t *testing.T) {
+	g := NewGame()
+	for _, mode := range []Mode{{Rounds: -1}, {EliminateEvery: -1}, {Rounds: 256}} {
+		if err := g.SetMode(mode); err == nil {
+			t.Errorf("expected an error setting %+v", mode)
+		}
+	}
+
+	g.AddPlayer(NewHumanPlayer("Ann"))
+	g.AddTeam("Red", "Ann")
+	g.SetMode(Mode{EliminateEvery: 2})
+	if err := g.Begin(); err == nil {
+		t.Error("expected an error eliminating with teams")
+	}
+}
+
+func TestMatch(t *testing.T) {
+	if _, err := NewMatch(0); err == nil {
+		t.Error("expected an error for a match without games")
+	}
+
+	match, _ := NewMatch(3)
+	if err := match.Record(NewGame()); err == nil {
+		t.Error("expected an error recording an unfinished game")
+	}
+
+	timid := thresholdAgent{"Timid", 50}
+	greedy := thresholdAgent{"Greedy", 400}
+	for seed := int64(1); !match.Over(); seed++ {
+		g := NewGame()
+		g.SetSeed(seed)
+		g.AddPlayer(timid)
+		g.AddPlayer(greedy)
+		playQuietly(t, g)
+
+		if err := match.Record(g); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	standings := match.Standings()
+	if match.Played() < 2 || standings[0].Wins < 2 {
+		t.Errorf("expected someone to win 2 of 3, got %+v after %d games", standings, match.Played())
+	}
+	if winners := match.Winners(); len(winners) != 1 || winners[0] != standings[0].Name {
+		t.Errorf("unexpected winners: %v", winners)
+	}
+	if err := match.Record(NewGame()); err == nil {
+		t.Error("expected an error recording after the match is over")
+	}
+}
+
+func TestModeLastHumanKnockedOut(t *testing.T) {
+	g := NewGame()
+	g.SetSeed(42)
+	g.SetMode(Mode{Rounds: 3, EliminateEvery: 1})
+	g.AddPlayer(thresholdAgent{"Timid", 1})
+	g.AddPlayer(thresholdAgent{"Steady", 1})
+	g.AddPlayer(NewHumanPlayer("Ann"))
+	if err := g.Begin(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Ann never banks, so she is knocked out after the first round
+	for !g.results.players["Ann"].eliminated {
+		if _, err := g.Roll(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !g.onlyAI {
+		t.Error("expected only AI Agents to be playing, so nobody is prompted, once Ann was knocked out")
+	}
+}
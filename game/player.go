@@ -15,6 +15,9 @@ type Player interface {
 	 *       agent banked or not so as to always provide the game data to the agents.
 	 *       If the agent has already banked the game will preform a noop.
 	 *
+	 * @note Called on its own goroutine at the same time as the other agents, with a
+	 *       read only copy of the game that can only be used to get its data.
+	 *
 	 * @return True if the player wants to bank, otherwise false
 	 */
 	Bank(*Game) bool
@@ -3,17 +3,15 @@ package game
 import (
 	"bufio"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 
+	"github.com/Sparhawk96/bank-ais/i18n"
 	"github.com/Sparhawk96/bank-ais/table"
 )
 
-const (
-	PROMPT      = "> "
-	MAIN_PROMPT = "Enter '?' for help\n\r" + PROMPT
-	BANK_PROMPT = "Enter 'd' or 'done' to submit\n\r" + PROMPT
-)
+const PROMPT = "> "
 
 type PromptRequest int
 
@@ -21,38 +19,47 @@ const (
 	PRINT_POINTS PromptRequest = iota
 	PLAYERS_BANK
 	ROLL_DICE
+	PRINT_SCOREBOARD
+	UNDO
+	JOIN
+	LEAVE
 )
 
+// Request made by each command of the main prompt
+var promptRequests = map[i18n.Command]PromptRequest{
+	i18n.CMD_POINTS:     PRINT_POINTS,
+	i18n.CMD_BANK:       PLAYERS_BANK,
+	i18n.CMD_SCOREBOARD: PRINT_SCOREBOARD,
+	i18n.CMD_UNDO:       UNDO,
+	i18n.CMD_JOIN:       JOIN,
+	i18n.CMD_LEAVE:      LEAVE,
+	i18n.CMD_ROLL:       ROLL_DICE,
+}
+
 /**
  * Prompts real players for some kind of action
  *
- * @return The request from the real players
+ * @return The request from the real players, rolling the dice once the input is done
  */
 func prompt() PromptRequest {
-	var request PromptRequest
+	for {
+		input := GetInput(i18n.T(i18n.PROMPT_MAIN, i18n.Choices(i18n.CMD_HELP))+"\n\r"+PROMPT, true)
 
-	for keepPrompting := true; keepPrompting; {
-		input := GetInput(MAIN_PROMPT, true)
+		// Nobody is left to answer once the input is done, so keep rolling until the game ends
+		if inputDone && input == "" {
+			return ROLL_DICE
+		}
 
 		// Determine Players Action
-		switch input {
-		case "?", "help", "h":
+		command, found := i18n.Lookup(input, i18n.MENU_COMMANDS...)
+		if request, isRequest := promptRequests[command]; isRequest {
+			return request
+		} else if found {
 			printPromptMenu()
-		case "p", "points", "pp", "print points", "player points":
-			keepPrompting = false
-			request = PRINT_POINTS
-		case "b", "bank", "pb", "player bank", "players bank", "bp", "bank points":
-			keepPrompting = false
-			request = PLAYERS_BANK
-		case "", "r", "roll", "rd", "roll dice":
-			keepPrompting = false
-			request = ROLL_DICE
-		default:
-			fmt.Printf("Invalid Input: '%s'\n\r", input)
+		} else {
+			printMsg(i18n.PROMPT_INVALID_INPUT, input)
 		}
 	}
-
-	return request
 }
 
 /**
@@ -61,33 +68,31 @@ func prompt() PromptRequest {
 func printPromptMenu() {
 	menu := new(table.Table)
 
-	actHdr := "Action"
-	cmdsHdr := "Commands"
-	descHdr := "Description"
+	actHdr := i18n.T(i18n.MENU_ACTION)
+	cmdsHdr := i18n.T(i18n.MENU_COMMANDS_HDR)
+	descHdr := i18n.T(i18n.MENU_DESCRIPTION)
 
 	menu.CreateColumn(actHdr, table.LEFT, 0)
 	menu.CreateColumn(cmdsHdr, table.LEFT, 0)
 	menu.CreateColumn(descHdr, table.LEFT, 0)
 
-	menu.AddEntry(map[string]any{
-		actHdr:  "Player Points",
-		cmdsHdr: "[p, points, print points]",
-		descHdr: "Prints the current player points",
-	})
-
-	menu.AddEntry(map[string]any{
-		actHdr:  "Bank Points",
-		cmdsHdr: "[b, bank, bank points]",
-		descHdr: "Enables players to bank the current points",
-	})
-
-	menu.AddEntry(map[string]any{
-		actHdr:  "Roll Dice",
-		cmdsHdr: "[r, roll, roll dice]",
-		descHdr: "Keep going and roll the dice",
-	})
-
-	fmt.Println(menu)
+	for _, command := range i18n.MENU_COMMANDS {
+		aliases := make([]string, 0)
+		for _, alias := range i18n.Aliases(command) {
+			if alias == "" {
+				alias = i18n.T(i18n.MENU_ENTER)
+			}
+			aliases = append(aliases, alias)
+		}
+
+		menu.AddEntry(map[string]any{
+			actHdr:  i18n.T(i18n.MenuAction(command)),
+			cmdsHdr: "[" + strings.Join(aliases, ", ") + "]",
+			descHdr: i18n.T(i18n.MenuDescription(command)),
+		})
+	}
+
+	fmt.Fprintln(output, menu)
 }
 
 /**
@@ -105,14 +110,14 @@ func getBankingPlayers(players []Player) []string {
 	for idx, player := range players {
 		// Human Players can't bank for AI Agents
 		if !player.AiAgent() {
-			fmt.Printf("%d) %s\n\r", idx+1, player.Name())
+			fmt.Fprintf(output, "%d) %s\n\r", idx+1, player.Name())
 			playerMap[player.Name()] = true
 			posPlayerMap[fmt.Sprint(idx+1)] = player.Name()
 		}
 	}
-	fmt.Println("")
+	fmt.Fprintln(output, "")
 
-	prompt := BANK_PROMPT
+	prompt := i18n.T(i18n.PROMPT_BANK, i18n.Choices(i18n.CMD_DONE)) + "\n\r" + PROMPT
 	for keepPrompting := true; keepPrompting; prompt = PROMPT {
 		input := GetInput(prompt, false)
 
@@ -120,45 +125,92 @@ func getBankingPlayers(players []Player) []string {
 			playersBanking = append(playersBanking, input)
 		} else if playerMap[posPlayerMap[input]] {
 			playersBanking = append(playersBanking, posPlayerMap[input])
-		} else if strings.ToLower(input) == "d" ||
-			strings.ToLower(input) == "done" ||
-			strings.ToLower(input) == "submit" {
-
+		} else if i18n.Is(i18n.CMD_DONE, input) || inputDone {
 			keepPrompting = false
 		} else {
-			fmt.Printf("Invalid Player Name or Number: %s\n\r", input)
+			printMsg(i18n.PROMPT_INVALID_PLAYER, input)
 		}
 
 		if len(playerMap) == len(playersBanking) {
 			keepPrompting = false
-			fmt.Println("All human players have banked.")
+			fmt.Fprintln(output, i18n.T(i18n.MSG_ALL_HUMANS_BANKED))
 		}
 	}
 
-	fmt.Println()
+	fmt.Fprintln(output)
 	return playersBanking
 }
 
-var inputReader *bufio.Reader
+/**
+ * Prints a message in the current locale on its own line
+ *
+ * @param key Message to print
+ * @param args Values of the message's format verbs
+ */
+func printMsg(key i18n.Key, args ...any) {
+	fmt.Fprint(output, i18n.T(key, args...)+"\n\r")
+}
+
+var (
+	inputReader *bufio.Reader
+	inputEcho   bool
+	inputDone   bool
+
+	output io.Writer = os.Stdout
+)
+
+/**
+ * Sets where the terminal game reads the players' input from, stdin by default
+ *
+ * @param in Reader of the input, such as a script of commands
+ * @param echo If True the input is printed after its prompt, as a terminal would show it
+ */
+func SetInput(in io.Reader, echo bool) {
+	inputReader = bufio.NewReader(in)
+	inputEcho = echo
+	inputDone = false
+}
 
 /**
- * Gets input from reader (stdin) and trims spaces\
+ * Sets where the terminal game is printed to, stdout by default
  *
- * @param prompt Sends a prompt to stdout requesting input from the user
+ * @param out Writer of the output
+ */
+func SetOutput(out io.Writer) {
+	output = out
+}
+
+/**
+ * Dictates if every input has been read
+ *
+ * @return True if the input reached its end, otherwise false
+ */
+func InputDone() bool {
+	return inputDone
+}
+
+/**
+ * Gets input from the reader (stdin by default) and trims spaces
+ *
+ * @param prompt Sends a prompt to the output requesting input from the user
  * @param lowerCase If True the input is lower cased
  *
- * @return The requested input
+ * @return The requested input, empty once the input is done
  */
 func GetInput(prompt string, lowerCase bool) string {
-	fmt.Print(prompt)
+	fmt.Fprint(output, prompt)
 
 	// TODO: how can i suppress all non-visible characters from being seen
 	if inputReader == nil {
 		inputReader = bufio.NewReader(os.Stdin)
 	}
 
-	input, _ := inputReader.ReadString('\n')
+	input, err := inputReader.ReadString('\n')
+	inputDone = err != nil
 	input = strings.TrimSpace(input)
+	if inputEcho {
+		fmt.Fprint(output, input+"\n\r")
+	}
 	if lowerCase {
 		input = strings.ToLower(input)
 	}
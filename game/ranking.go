@@ -0,0 +1,309 @@
+package game
+
+import (
+	"log/slog"
+	"math/rand"
+)
+
+/**
+ * Ranks players by their points, most points first.
+ *
+ * Players with the same points keep the order they were added in, so ties
+ * are always displayed the same way. Backed by a treap that tracks the size
+ * of every subtree so updates and rank queries are O(log n).
+ */
+type ranking struct {
+	root  *rankNode
+	nodes map[string]*rankNode
+	seq   int
+	r     *rand.Rand
+
+	logger *slog.Logger // Traces players moving up or down, nil to not trace
+}
+
+type rankNode struct {
+	name     string
+	pts      uint
+	seq      int // Order the player was added in, breaks ties
+	priority int64
+	size     int // Number of nodes in the subtree
+	left     *rankNode
+	right    *rankNode
+}
+
+/**
+ * Creates an empty ranking
+ *
+ * @return The ranking
+ */
+func newRanking() *ranking {
+	return &ranking{
+		nodes: make(map[string]*rankNode),
+		r:     rand.New(rand.NewSource(1)), // Only shapes the tree, never the order
+	}
+}
+
+/**
+ * Adds a player to the ranking
+ *
+ * @param name Name of the player
+ * @param pts Points the player starts with
+ *
+ * @return False if the player is already ranked, otherwise true
+ */
+func (rk *ranking) add(name string, pts uint) bool {
+	if _, has := rk.nodes[name]; has {
+		return false
+	}
+
+	node := &rankNode{name: name, pts: pts, seq: rk.seq, priority: rk.r.Int63(), size: 1}
+	rk.seq++
+	rk.nodes[name] = node
+	rk.root = insertNode(rk.root, node)
+	return true
+}
+
+/**
+ * Removes a player from the ranking
+ *
+ * @param name Name of the player
+ */
+func (rk *ranking) remove(name string) {
+	if node, has := rk.nodes[name]; has {
+		rk.root = removeNode(rk.root, node)
+		delete(rk.nodes, name)
+	}
+}
+
+/**
+ * Changes the points of a player, moving them up or down the ranking
+ *
+ * @param name Name of the player
+ * @param pts The player's new total points
+ */
+func (rk *ranking) update(name string, pts uint) {
+	node, has := rk.nodes[name]
+	if !has || node.pts == pts {
+		return
+	}
+
+	before := 0
+	if rk.logger != nil {
+		before = rk.position(name)
+	}
+
+	rk.root = removeNode(rk.root, node)
+	node.pts = pts
+	node.size = 1
+	node.left, node.right = nil, nil
+	rk.root = insertNode(rk.root, node)
+
+	if rk.logger == nil {
+		return
+	} else if after := rk.position(name); after != before {
+		rk.logger.Debug("ranking changed", "name", name, "points", pts, "from", before, "to", after)
+	}
+}
+
+/**
+ * Gets the number of ranked players
+ *
+ * @return Number of players
+ */
+func (rk *ranking) len() int {
+	return size(rk.root)
+}
+
+/**
+ * Gets the position of a player, where ties are broken by the order added
+ *
+ * @param name Name of the player
+ *
+ * @return 1 based position, or 0 if the player isn't ranked
+ */
+func (rk *ranking) position(name string) int {
+	node, has := rk.nodes[name]
+	if !has {
+		return 0
+	}
+	return countBefore(rk.root, node.pts, node.seq) + 1
+}
+
+/**
+ * Gets the rank of a player, where tied players share the same rank
+ *
+ * @example Points of 50, 30, 30, 10 are ranked 1, 2, 2, 4
+ *
+ * @param name Name of the player
+ *
+ * @return 1 based rank, or 0 if the player isn't ranked
+ */
+func (rk *ranking) rank(name string) int {
+	node, has := rk.nodes[name]
+	if !has {
+		return 0
+	}
+	return countBefore(rk.root, node.pts, -1) + 1
+}
+
+/**
+ * Gets the name of the player at a position
+ *
+ * @param pos 1 based position
+ *
+ * @return Name of the player and true, or false if the position is out of range
+ */
+func (rk *ranking) at(pos int) (string, bool) {
+	if pos < 1 || rk.len() < pos {
+		return "", false
+	}
+
+	node := rk.root
+	for idx := pos - 1; ; {
+		leftSize := size(node.left)
+		switch {
+		case idx < leftSize:
+			node = node.left
+		case idx == leftSize:
+			return node.name, true
+		default:
+			idx -= leftSize + 1
+			node = node.right
+		}
+	}
+}
+
+/**
+ * Gets all of the players at a rank
+ *
+ * @param rank 1 based rank
+ *
+ * @return Names of the players in order, empty if no player has the rank
+ */
+func (rk *ranking) playersAtRank(rank int) []string {
+	players := make([]string, 0)
+
+	first, has := rk.at(rank)
+	if !has || rk.rank(first) != rank {
+		return players
+	}
+
+	pts := rk.nodes[first].pts
+	for pos := rank; ; pos++ {
+		name, has := rk.at(pos)
+		if !has || rk.nodes[name].pts != pts {
+			break
+		}
+		players = append(players, name)
+	}
+	return players
+}
+
+/**
+ * Gets all of the players in ranking order
+ *
+ * @return Names of the players, most points first
+ */
+func (rk *ranking) players() []string {
+	players := make([]string, 0, rk.len())
+
+	var walk func(node *rankNode)
+	walk = func(node *rankNode) {
+		if node != nil {
+			walk(node.left)
+			players = append(players, node.name)
+			walk(node.right)
+		}
+	}
+	walk(rk.root)
+
+	return players
+}
+
+/**
+ * Dictates if a node is ranked ahead of a key
+ *
+ * @return True if the node has more points, or equal points and was added earlier
+ */
+func (node *rankNode) ahead(pts uint, seq int) bool {
+	return node.pts > pts || (node.pts == pts && node.seq < seq)
+}
+
+func size(node *rankNode) int {
+	if node == nil {
+		return 0
+	}
+	return node.size
+}
+
+func (node *rankNode) resize() {
+	node.size = size(node.left) + size(node.right) + 1
+}
+
+/**
+ * Splits a tree into the nodes ranked ahead of a key and the rest
+ */
+func split(node *rankNode, pts uint, seq int) (*rankNode, *rankNode) {
+	if node == nil {
+		return nil, nil
+	}
+
+	if node.ahead(pts, seq) {
+		left, right := split(node.right, pts, seq)
+		node.right = left
+		node.resize()
+		return node, right
+	}
+
+	left, right := split(node.left, pts, seq)
+	node.left = right
+	node.resize()
+	return left, node
+}
+
+/**
+ * Joins two trees where every node of the first is ranked ahead of the second
+ */
+func merge(left, right *rankNode) *rankNode {
+	switch {
+	case left == nil:
+		return right
+	case right == nil:
+		return left
+	case left.priority > right.priority:
+		left.right = merge(left.right, right)
+		left.resize()
+		return left
+	default:
+		right.left = merge(left, right.left)
+		right.resize()
+		return right
+	}
+}
+
+func insertNode(root, node *rankNode) *rankNode {
+	left, right := split(root, node.pts, node.seq)
+	return merge(merge(left, node), right)
+}
+
+func removeNode(root, node *rankNode) *rankNode {
+	left, rest := split(root, node.pts, node.seq)
+	_, right := split(rest, node.pts, node.seq+1)
+	return merge(left, right)
+}
+
+/**
+ * Counts the nodes ranked ahead of a key
+ */
+func countBefore(node *rankNode, pts uint, seq int) int {
+	count := 0
+	for node != nil {
+		if node.ahead(pts, seq) {
+			count += size(node.left) + 1
+			node = node.right
+		} else {
+			node = node.left
+		}
+	}
+	return count
+}
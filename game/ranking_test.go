@@ -0,0 +1,153 @@
+package game
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+/**
+ * Sort based ranking the treap is compared to
+ */
+type referenceRanking struct {
+	pts   map[string]uint
+	order []string // Order the players were added in
+}
+
+func (ref *referenceRanking) sorted() []string {
+	sorted := append([]string{}, ref.order...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return ref.pts[sorted[i]] > ref.pts[sorted[j]]
+	})
+	return sorted
+}
+
+func (ref *referenceRanking) rank(name string) int {
+	rank := 1
+	for _, other := range ref.order {
+		if ref.pts[other] > ref.pts[name] {
+			rank++
+		}
+	}
+	return rank
+}
+
+func (ref *referenceRanking) remove(name string) {
+	delete(ref.pts, name)
+	for idx, other := range ref.order {
+		if other == name {
+			ref.order = append(ref.order[:idx], ref.order[idx+1:]...)
+			break
+		}
+	}
+}
+
+func checkRanking(t *testing.T, rk *ranking, ref *referenceRanking) {
+	t.Helper()
+
+	expected := ref.sorted()
+	ranks := make(map[string]int, len(expected))
+	for _, name := range expected {
+		ranks[name] = ref.rank(name)
+	}
+
+	if actual := rk.players(); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("order: got %v, expected %v", actual, expected)
+	}
+
+	for pos, name := range expected {
+		if actual := rk.position(name); actual != pos+1 {
+			t.Fatalf("position of %s: got %d, expected %d", name, actual, pos+1)
+		}
+		if actual, _ := rk.at(pos + 1); actual != name {
+			t.Fatalf("player at position %d: got %s, expected %s", pos+1, actual, name)
+		}
+		if actual, expected := rk.rank(name), ranks[name]; actual != expected {
+			t.Fatalf("rank of %s: got %d, expected %d", name, actual, expected)
+		}
+	}
+
+	for rank := 0; rank <= len(expected)+1; rank++ {
+		atRank := make([]string, 0)
+		for _, name := range expected {
+			if ranks[name] == rank {
+				atRank = append(atRank, name)
+			}
+		}
+		if actual := rk.playersAtRank(rank); !reflect.DeepEqual(actual, atRank) {
+			t.Fatalf("players at rank %d: got %v, expected %v", rank, actual, atRank)
+		}
+	}
+}
+
+func TestRankingRandomized(t *testing.T) {
+	for seed := int64(0); seed < 20; seed++ {
+		r := rand.New(rand.NewSource(seed))
+		rk := newRanking()
+		ref := &referenceRanking{pts: make(map[string]uint)}
+		next := 0
+
+		for op := 0; op < 200; op++ {
+			switch choice := r.Intn(10); {
+			case choice < 3 || len(ref.order) == 0:
+				name := fmt.Sprintf("p%d", next)
+				next++
+				pts := uint(r.Intn(5) * 10) // Small range to force ties
+				rk.add(name, pts)
+				ref.pts[name] = pts
+				ref.order = append(ref.order, name)
+			case choice < 9:
+				name := ref.order[r.Intn(len(ref.order))]
+				pts := uint(r.Intn(8) * 10) // Moves players up and down
+				rk.update(name, pts)
+				ref.pts[name] = pts
+			default:
+				name := ref.order[r.Intn(len(ref.order))]
+				rk.remove(name)
+				ref.remove(name)
+			}
+
+			checkRanking(t, rk, ref)
+		}
+	}
+}
+
+func TestRankingAddTwice(t *testing.T) {
+	rk := newRanking()
+	if !rk.add("a", 10) {
+		t.Fatal("expected the first add to succeed")
+	}
+	if rk.add("a", 20) {
+		t.Fatal("expected adding the same player twice to fail")
+	}
+	if rk.len() != 1 {
+		t.Fatalf("expected 1 player, got %d", rk.len())
+	}
+}
+
+func TestResultsLastPlayerOvertakes(t *testing.T) {
+	r := new(results)
+	for _, name := range []string{"a", "b", "c"} {
+		r.addPlayer(thresholdAgent{name, 0})
+	}
+
+	r.playerBanks(r.players["a"], 10)
+	r.playerBanks(r.players["b"], 20)
+	r.playerBanks(r.players["c"], 15) // Last place moving up used to panic
+
+	order := make([]string, 0)
+	for _, player := range r.rankedPlayers() {
+		order = append(order, player.Name())
+	}
+	if expected := []string{"b", "c", "a"}; !reflect.DeepEqual(order, expected) {
+		t.Errorf("got %v, expected %v", order, expected)
+	}
+
+	r.playerBanks(r.players["a"], 10)
+	// Ties are in the order the players were added
+	if leaders := r.leaders(); len(leaders) != 2 || leaders[0].Name() != "a" || leaders[1].Name() != "b" {
+		t.Errorf("expected 'a' and 'b' to be tied for first, got %v", leaders)
+	}
+}
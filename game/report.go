@@ -0,0 +1,232 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/Sparhawk96/bank-ais/i18n"
+	"github.com/Sparhawk96/bank-ais/table"
+)
+
+/**
+ * Summary of a game once it's over
+ */
+type Report struct {
+	Rounds       int            `json:"rounds"`
+	LongestRound RoundStat      `json:"longestRound"`
+	HighestPot   RoundStat      `json:"highestPot"`
+	Sevens       int            `json:"sevens"`  // Every 7 rolled, including the safe ones
+	Doubles      int            `json:"doubles"` // Every doubles rolled, including the safe ones
+	Players      []PlayerReport `json:"players"`
+	Faults       []Fault        `json:"faults,omitempty"` // Every fault made by the AI Agents
+	Undos        []Undo         `json:"undos,omitempty"`  // Every bank action the host undid
+}
+
+type RoundStat struct {
+	Round int  `json:"round"` // 1 based round number
+	Rolls int  `json:"rolls"`
+	Pot   uint `json:"pot"` // Highest points reached in the round
+}
+
+type PlayerReport struct {
+	Name        string  `json:"name"`
+	Points      uint    `json:"points"`
+	Banks       int     `json:"banks"`       // Number of rounds the player banked
+	Banked      uint    `json:"banked"`      // Total points banked
+	AverageBank float64 `json:"averageBank"` // Average points banked when the player banked
+	LeftOnTable uint    `json:"leftOnTable"` // Pot when a 7 was rolled minus what the player banked
+
+	// What the player's banked points would have totaled if every
+	// bank happened one roll earlier or one roll later
+	BankedEarlier uint `json:"bankedEarlier"`
+	BankedLater   uint `json:"bankedLater"`
+
+	// Banks where rolling once more never happened because everyone had banked,
+	// these count as the same points for BankedLater
+	LaterUnknown int `json:"laterUnknown"`
+}
+
+/**
+ * Creates the post game report
+ *
+ * @return The report of all the rounds played thus far, empty on the views given to AI Agents
+ */
+func (g *Game) Report() Report {
+	if g.frozen != nil {
+		return Report{}
+	}
+
+	rep := Report{Players: make([]PlayerReport, 0, len(g.players))}
+
+	for num, record := range g.results.history {
+		rolls := g.rounds[num].rolls
+		highest := uint(0)
+		for _, pot := range record.pots {
+			highest = max(highest, pot)
+		}
+
+		if rep.Rounds == 0 || rep.LongestRound.Rolls < len(rolls) {
+			rep.LongestRound = RoundStat{Round: num + 1, Rolls: len(rolls), Pot: highest}
+		}
+		if rep.Rounds == 0 || rep.HighestPot.Pot < highest {
+			rep.HighestPot = RoundStat{Round: num + 1, Rolls: len(rolls), Pot: highest}
+		}
+		rep.Rounds++
+
+		for _, dice := range rolls {
+			if dice[0]+dice[1] == 7 {
+				rep.Sevens++
+			}
+			if dice[0] == dice[1] {
+				rep.Doubles++
+			}
+		}
+	}
+
+	for _, player := range g.results.rankedPlayers() {
+		pr := PlayerReport{Name: player.Name(), Points: player.pts}
+		handicap := player.handicap
+
+		for _, record := range g.results.history {
+			pts, has := record.banks[player.Name()]
+			if !has {
+				continue
+			}
+			pr.Banks++
+			pr.Banked += pts
+
+			// A multiplier can bank more than the pot
+			if pot := handicap.apply(record.pot); record.busted && pot > pts {
+				pr.LeftOnTable += pot - pts
+			}
+
+			// Number of rolls before the player banked
+			rolls := record.bankRolls[player.Name()]
+			if rolls >= 2 {
+				pr.BankedEarlier += handicap.apply(record.pots[rolls-2])
+			}
+			switch {
+			case rolls < len(record.pots):
+				pr.BankedLater += handicap.apply(record.pots[rolls])
+			case !record.busted:
+				pr.BankedLater += pts
+				pr.LaterUnknown++
+			}
+		}
+
+		if pr.Banks > 0 {
+			pr.AverageBank = float64(pr.Banked) / float64(pr.Banks)
+		}
+		rep.Players = append(rep.Players, pr)
+	}
+
+	rep.Faults = g.Faults()
+	rep.Undos = g.Undos()
+	return rep
+}
+
+/**
+ * Creates the tables the report is displayed with
+ *
+ * @return Table of the game stats and table of the player stats
+ */
+func (rep Report) tables() (*table.Table, *table.Table) {
+	statHdr := i18n.T(i18n.HDR_STAT)
+	valueHdr := i18n.T(i18n.HDR_VALUE)
+
+	stats := new(table.Table)
+	stats.Title = i18n.T(i18n.TITLE_GAME_REPORT)
+	stats.CreateColumn(statHdr, table.LEFT, 0)
+	stats.CreateColumn(valueHdr, table.LEFT, 0)
+	stats.AddEntry(map[string]any{statHdr: i18n.T(i18n.CELL_ROUNDS), valueHdr: rep.Rounds})
+	stats.AddEntry(map[string]any{statHdr: i18n.T(i18n.CELL_LONGEST_ROUND),
+		valueHdr: i18n.T(i18n.CELL_ROUND_ROLLS, rep.LongestRound.Round, rep.LongestRound.Rolls)})
+	stats.AddEntry(map[string]any{statHdr: i18n.T(i18n.CELL_HIGHEST_POT),
+		valueHdr: i18n.T(i18n.CELL_POT_IN_ROUND, rep.HighestPot.Pot, rep.HighestPot.Round)})
+	stats.AddEntry(map[string]any{statHdr: i18n.T(i18n.CELL_SEVENS), valueHdr: rep.Sevens})
+	stats.AddEntry(map[string]any{statHdr: i18n.T(i18n.CELL_DOUBLES), valueHdr: rep.Doubles})
+
+	playerHdr := i18n.T(i18n.HDR_PLAYER)
+	pointsHdr := i18n.T(i18n.HDR_POINTS)
+	banksHdr := i18n.T(i18n.HDR_BANKS)
+	avgHdr := i18n.T(i18n.HDR_AVG_BANK)
+	leftHdr := i18n.T(i18n.HDR_LEFT_ON_TABLE)
+	earlierHdr := i18n.T(i18n.HDR_ROLL_EARLIER)
+	laterHdr := i18n.T(i18n.HDR_ROLL_LATER)
+
+	players := new(table.Table)
+	players.Title = i18n.T(i18n.TITLE_PLAYER_REPORT)
+	players.CreateColumn(playerHdr, table.LEFT, 0)
+	for _, hdr := range []string{pointsHdr, banksHdr, avgHdr, leftHdr, earlierHdr, laterHdr} {
+		players.CreateColumn(hdr, table.RIGHT, 0)
+	}
+	players.SetFormatter(avgHdr, func(val any) string { return fmt.Sprintf("%.1f", val) })
+
+	// Shows how the what if points compare to what the player banked
+	whatIf := func(pts, banked uint) string {
+		if pts >= banked {
+			return fmt.Sprintf("%d (+%d)", pts, pts-banked)
+		}
+		return fmt.Sprintf("%d (-%d)", pts, banked-pts)
+	}
+
+	for _, pr := range rep.Players {
+		later := whatIf(pr.BankedLater, pr.Banked)
+		if pr.LaterUnknown > 0 {
+			later += " " + i18n.T(i18n.CELL_UNKNOWN, pr.LaterUnknown)
+		}
+
+		players.AddEntry(map[string]any{
+			playerHdr:  pr.Name,
+			pointsHdr:  pr.Points,
+			banksHdr:   pr.Banks,
+			avgHdr:     pr.AverageBank,
+			leftHdr:    pr.LeftOnTable,
+			earlierHdr: whatIf(pr.BankedEarlier, pr.Banked),
+			laterHdr:   later,
+		})
+	}
+
+	return stats, players
+}
+
+/**
+ * Renders the report's tables
+ *
+ * @param r Renderer for the tables such as table.TEXT or table.MARKDOWN
+ *
+ * @return The rendered report
+ */
+func (rep Report) Render(r table.Renderer) string {
+	stats, players := rep.tables()
+	out := stats.Render(r) + "\n" + players.Render(r)
+	if len(rep.Faults) > 0 {
+		out += "\n" + faultTable(rep.Faults).Render(r)
+	}
+	if len(rep.Undos) > 0 {
+		out += "\n" + undoTable(rep.Undos).Render(r)
+	}
+	return out
+}
+
+/**
+ * Gets the report as Markdown
+ *
+ * @return The Markdown report
+ */
+func (rep Report) Markdown() string {
+	return "## " + i18n.T(i18n.TITLE_REPORT) + "\n\n" + rep.Render(table.MARKDOWN)
+}
+
+/**
+ * Gets the report as JSON
+ *
+ * @return The JSON report, or an error if it couldn't be encoded
+ */
+func (rep Report) JSON() ([]byte, error) {
+	return json.MarshalIndent(rep, "", "  ")
+}
+
+func (rep Report) String() string {
+	return rep.Render(table.TEXT)
+}
@@ -0,0 +1,78 @@
+package game
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestReport(t *testing.T) {
+	g := NewGame()
+	steady := thresholdAgent{"Steady", 0}
+	greedy := thresholdAgent{"Greedy", 0}
+	g.AddPlayer(steady)
+	g.AddPlayer(greedy)
+
+	// Round 1: 8, 70, 10 (safe rolls), doubles, then a 7
+	g.rounds[0].rolls = []Dice{{4, 4}, {3, 4}, {5, 5}, {2, 2}, {1, 6}}
+	g.results.startRound()
+	for _, pot := range []uint{8, 78, 88, 176} {
+		g.results.recordRoll(pot)
+		if pot == 78 {
+			g.results.playerBanks(steady, pot)
+		}
+	}
+	g.results.endRound(176, true)
+	g.results.unbankAllPlayers()
+
+	// Round 2: everyone banks after 2 rolls
+	g.rounds = append(g.rounds, round{rolls: []Dice{{1, 2}, {6, 6}}})
+	g.results.startRound()
+	g.results.recordRoll(3)
+	g.results.recordRoll(15)
+	g.results.playerBanks(steady, 15)
+	g.results.playerBanks(greedy, 15)
+	g.results.endRound(15, false)
+	g.results.unbankAllPlayers()
+
+	rep := g.Report()
+	if rep.Rounds != 2 || rep.Sevens != 2 || rep.Doubles != 4 {
+		t.Errorf("unexpected counts: %+v", rep)
+	}
+	if rep.LongestRound.Round != 1 || rep.LongestRound.Rolls != 5 {
+		t.Errorf("unexpected longest round: %+v", rep.LongestRound)
+	}
+	if rep.HighestPot.Round != 1 || rep.HighestPot.Pot != 176 {
+		t.Errorf("unexpected highest pot: %+v", rep.HighestPot)
+	}
+
+	if len(rep.Players) != 2 || rep.Players[0].Name != "Steady" {
+		t.Fatalf("unexpected players: %+v", rep.Players)
+	}
+	s := rep.Players[0]
+	if s.Banks != 2 || s.Banked != 93 || s.AverageBank != 46.5 || s.LeftOnTable != 98 {
+		t.Errorf("unexpected stats: %+v", s)
+	}
+	if s.BankedEarlier != 8+3 || s.BankedLater != 88+15 || s.LaterUnknown != 1 {
+		t.Errorf("unexpected what ifs: %+v", s)
+	}
+
+	md := rep.Markdown()
+	for _, expected := range []string{"## Bank Game Report", "| Steady |", "**Player Report**"} {
+		if !strings.Contains(md, expected) {
+			t.Errorf("markdown is missing %q:\n%s", expected, md)
+		}
+	}
+
+	data, err := rep.JSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded Report
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Players[1].Name != "Greedy" || decoded.HighestPot.Pot != 176 {
+		t.Errorf("JSON didn't round trip: %s", data)
+	}
+}
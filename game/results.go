@@ -1,10 +1,22 @@
 package game
 
-import "github.com/Sparhawk96/bank-ais/table"
+import (
+	"log/slog"
+
+	"github.com/Sparhawk96/bank-ais/i18n"
+	"github.com/Sparhawk96/bank-ais/table"
+)
 
 type results struct {
 	players            map[string]*playerNode
-	firstPlayer        *playerNode
+	ranking            *ranking
+	history            []roundRecord
+	faults             []Fault
+	undos              []Undo
+	teams              map[string]*team
+	eliminated         []*playerNode // In the order they were knocked out
+	teamRanking        *ranking
+	logger             *slog.Logger // nil if the game isn't traced
 	largestName        int
 	humanPlayers       int
 	bankedHumanPlayers int
@@ -13,10 +25,19 @@ type results struct {
 type playerNode struct {
 	Player
 
-	pts    uint
-	banked bool
-	ahead  *playerNode
-	behind *playerNode
+	pts          uint
+	banked       bool
+	faults       int
+	disqualified bool
+	team         *team // nil if not playing with teams
+	eliminated   bool
+	eliminatedIn uint8 // Round they were knocked out in
+	handicap     Handicap
+	start        uint  // Points the player started with
+	joinedIn     uint8 // Round a late joiner entered in, 0 if they played from the start
+	forfeited    bool
+	standIn      bool  // True if an AI Agent took over the seat of a human
+	leftIn       uint8 // Round the human left in when an AI Agent took over
 }
 
 /**
@@ -27,11 +48,14 @@ type playerNode struct {
 func (r *results) addPlayer(player Player) {
 	if r.players == nil {
 		r.players = make(map[string]*playerNode)
+		r.ranking = newRanking()
+		r.ranking.logger = r.rankingLogger("players")
 	}
 
 	if _, have := r.players[player.Name()]; !have {
 		pn := &playerNode{Player: player}
 		r.players[pn.Name()] = pn
+		r.ranking.add(pn.Name(), pn.pts)
 
 		if !pn.AiAgent() {
 			r.humanPlayers++
@@ -40,19 +64,23 @@ func (r *results) addPlayer(player Player) {
 		if nameLen := len(pn.Name()); r.largestName < nameLen {
 			r.largestName = nameLen
 		}
-
-		if r.firstPlayer == nil {
-			r.firstPlayer = pn
-		} else {
-			lastPlayer := r.firstPlayer
-			for lastPlayer.behind != nil {
-				lastPlayer = lastPlayer.behind
-			}
-			lastPlayer.behind = pn
-		}
 	}
 }
 
+/**
+ * Sets a player's handicap, giving them their starting points
+ *
+ * @param player Player who is handicapped
+ * @param handicap Handicap of the player
+ */
+func (r *results) setHandicap(player Player, handicap Handicap) {
+	pn := r.players[player.Name()]
+	pn.handicap = handicap
+	pn.start = handicap.StartingPoints
+	pn.pts = handicap.StartingPoints
+	r.ranking.update(pn.Name(), pn.pts)
+}
+
 /**
  * Marks a Player as Banked and updates their score
  *
@@ -70,33 +98,72 @@ func (r *results) playerBanks(player Player, pts uint) bool {
 		r.bankedHumanPlayers++
 	}
 
-	playerAhead := pn.ahead
-	for playerAhead != nil && playerAhead.pts < pn.pts {
-		playerAhead = playerAhead.ahead
+	r.ranking.update(pn.Name(), pn.pts)
+	if pn.team != nil {
+		pn.team.pts += pts
+		r.teamRanking.update(pn.team.name, pn.team.pts)
 	}
+	r.recordBank(player, pts)
 
-	// Did player get enough pts to move up in the ranking?
-	if playerAhead != pn.ahead {
-		// If moved it can't be in first place so
-		// there won't be a nil pointer dereference
-		pn.ahead.behind = pn.behind
-		pn.behind.ahead = pn.ahead
+	return r.humanPlayers == r.bankedHumanPlayers
+}
 
-		// Will the player be moved to first place?
-		if playerAhead == nil {
-			pn.ahead = nil
-			pn.behind = r.firstPlayer
-			r.firstPlayer.ahead = pn
-			r.firstPlayer = pn
-		} else {
-			pn.ahead = playerAhead
-			pn.behind = playerAhead.behind
-			playerAhead.behind = pn
-			playerAhead.behind.ahead = pn
+/**
+ * Gets all of the players in ranking order
+ *
+ * @return Players with the most points first, ties in the order they were added.
+ *         When playing with teams, players are grouped by their team's ranking.
+ *         Players knocked out rank below everyone still playing.
+ */
+func (r *results) rankedPlayers() []*playerNode {
+	ranked := make([]*playerNode, 0, len(r.players))
+	if len(r.teams) > 0 {
+		for _, t := range r.rankedTeams() {
+			ranked = append(ranked, r.rankedMembers(t)...)
+		}
+	} else if r.ranking != nil {
+		for _, name := range r.ranking.players() {
+			if pn := r.players[name]; !pn.eliminated {
+				ranked = append(ranked, pn)
+			}
+		}
+
+		// Whoever lasted longer ranks higher
+		for idx := len(r.eliminated) - 1; idx >= 0; idx-- {
+			ranked = append(ranked, r.eliminated[idx])
 		}
 	}
+	return ranked
+}
 
-	return r.humanPlayers == r.bankedHumanPlayers
+/**
+ * Gets the players in first place
+ *
+ * @return All players tied for the most points, or every member of the
+ *         teams tied for the most points
+ */
+func (r *results) leaders() []Player {
+	leaders := make([]Player, 0)
+	if len(r.teams) > 0 {
+		for _, name := range r.teamRanking.playersAtRank(1) {
+			for _, member := range r.rankedMembers(r.teams[name]) {
+				leaders = append(leaders, member.Player)
+			}
+		}
+	} else if len(r.eliminated) > 0 {
+		ranked := r.rankedPlayers()
+		for _, pn := range ranked {
+			if pn.eliminated || pn.pts != ranked[0].pts {
+				break
+			}
+			leaders = append(leaders, pn.Player)
+		}
+	} else if r.ranking != nil {
+		for _, name := range r.ranking.playersAtRank(1) {
+			leaders = append(leaders, r.players[name].Player)
+		}
+	}
+	return leaders
 }
 
 /**
@@ -115,11 +182,9 @@ func (r *results) playerBanked(player Player) bool {
  */
 func (r *results) unbankAllPlayers() {
 	r.bankedHumanPlayers = 0
-	player := r.firstPlayer
 
-	for player != nil {
+	for _, player := range r.players {
 		player.banked = false
-		player = player.behind
 	}
 }
 
@@ -131,9 +196,9 @@ func (r *results) unbankAllPlayers() {
 func (r *results) getUnbankedPlayers() []Player {
 	unbankedPlayers := make([]Player, 0)
 
-	for player := r.firstPlayer; player != nil; player = player.behind {
-		if !player.banked {
-			unbankedPlayers = append(unbankedPlayers, player)
+	for _, player := range r.rankedPlayers() {
+		if !player.banked && !player.eliminated {
+			unbankedPlayers = append(unbankedPlayers, player.Player)
 		}
 	}
 
@@ -150,42 +215,110 @@ func (r *results) getUnbankedPlayers() []Player {
 func (r *results) getPlayerData(player Player) PlayerDataSnapshot {
 	p := r.players[player.Name()]
 
-	return PlayerDataSnapshot{
-		Name:   p.Name(),
-		Points: p.pts,
-		Banked: p.banked,
+	data := PlayerDataSnapshot{
+		Name:    p.Name(),
+		Points:  p.pts,
+		Banked:  p.banked,
+		AiAgent: p.AiAgent(),
+	}
+	if p.team != nil {
+		data.Team = p.team.name
+	}
+	data.Eliminated = p.eliminated
+	data.JoinedRound = p.joinedIn
+	data.Forfeited = p.forfeited
+	data.StandIn = p.standIn
+	if !p.handicap.none() {
+		h := p.handicap
+		data.Handicap = &h
 	}
+	return data
 }
 
 func (r *results) String() string {
+	if len(r.teams) > 0 {
+		return r.teamTable().String()
+	}
+
 	t := new(table.Table)
+	t.HeaderHighlight = table.Highlight{Bold: true}
 
 	// Setup Headers
-	playerHdr := "Players"
-	aiAgentHdr := "AI Agent"
-	bankedHdr := "Banked"
-	pointsHdr := "Points"
+	playerHdr := i18n.T(i18n.HDR_PLAYERS)
+	aiAgentHdr := i18n.T(i18n.HDR_AI_AGENT)
+	bankedHdr := i18n.T(i18n.HDR_BANKED)
+	pointsHdr := i18n.T(i18n.HDR_POINTS)
+	faultsHdr := i18n.T(i18n.HDR_FAULTS)
+	outHdr := i18n.T(i18n.HDR_KNOCKED_OUT)
+	handicapHdr := i18n.T(i18n.HDR_HANDICAP)
+	seatHdr := i18n.T(i18n.HDR_SEAT)
 
 	t.CreateColumn(playerHdr, table.LEFT, 0)
 	t.CreateColumn(aiAgentHdr, table.CENTER, 0)
 	t.CreateColumn(bankedHdr, table.CENTER, 0)
 	t.CreateColumn(pointsHdr, table.LEFT, 0)
 
-	for player := r.firstPlayer; player != nil; player = player.behind {
+	// Only shown once an agent has faulted
+	if len(r.faults) > 0 {
+		t.CreateColumn(faultsHdr, table.RIGHT, 0)
+	}
+
+	// Only shown once a player is knocked out
+	if r.anyKnockedOut() {
+		t.CreateColumn(outHdr, table.LEFT, 0)
+	}
+
+	// Only shown once a player joins late or leaves
+	if r.anySeatChanges() {
+		t.CreateColumn(seatHdr, table.LEFT, 0)
+	}
+
+	if r.anyHandicaps() {
+		t.CreateColumn(handicapHdr, table.LEFT, 0)
+	}
+
+	leaders := make(map[string]bool)
+	for _, leader := range r.leaders() {
+		leaders[leader.Name()] = true
+	}
+
+	for _, player := range r.rankedPlayers() {
 		data := map[string]any{
 			playerHdr: player.Name(),
 			pointsHdr: player.pts,
 		}
 
 		if player.banked {
-			data[bankedHdr] = "✔"
+			data[bankedHdr] = table.Highlighted("✔", table.Highlight{Foreground: table.GREEN})
 		}
 
 		if player.AiAgent() {
+			data[playerHdr] = table.Highlighted(player.Name(), table.Highlight{Foreground: table.CYAN})
 			data[aiAgentHdr] = "✔"
 		}
 
-		t.AddEntry(data)
+		if player.disqualified {
+			data[faultsHdr] = table.Highlighted(i18n.T(i18n.CELL_FAULT_DQ, player.faults), table.Highlight{Foreground: table.RED})
+		} else if player.faults > 0 {
+			data[faultsHdr] = player.faults
+		}
+
+		if !player.handicap.none() {
+			data[handicapHdr] = player.handicap.String()
+		}
+
+		if player.eliminated && !player.forfeited {
+			data[outHdr] = i18n.T(i18n.CELL_KNOCKED_OUT_IN, player.eliminatedIn+1)
+		}
+		data[seatHdr] = player.seat()
+
+		if leaders[player.Name()] {
+			t.AddHighlightedEntry(data, table.Highlight{Bold: true})
+		} else if player.eliminated {
+			t.AddHighlightedEntry(data, table.Highlight{Dim: true})
+		} else {
+			t.AddEntry(data)
+		}
 	}
 
 	return t.String()
@@ -0,0 +1,205 @@
+package game
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/Sparhawk96/bank-ais/i18n"
+	"github.com/Sparhawk96/bank-ais/table"
+)
+
+const CHART_HEIGHT = 12
+
+// Fewest lines a chart is drawn with, so the top and bottom rows differ
+const MIN_CHART_HEIGHT = 2
+
+// Markers used to plot each player on the score chart
+var chartMarkers = []rune{'*', 'o', '+', 'x', '#', '@', '%', '&', '$', '=', '~', '^'}
+
+type roundRecord struct {
+	banks     map[string]uint // Points banked by each player, missing if they didn't bank
+	bankRolls map[string]int  // Number of rolls before each player banked
+	pots      []uint          // Points after each roll that didn't end the round
+	pot       uint            // Points when the round ended
+	busted    bool            // True if the round ended with a 7, otherwise all players banked
+}
+
+/**
+ * Starts recording a new round
+ */
+func (r *results) startRound() {
+	r.history = append(r.history, roundRecord{
+		banks:     make(map[string]uint),
+		bankRolls: make(map[string]int),
+	})
+}
+
+/**
+ * Records the points after a roll that didn't end the current round
+ *
+ * @param pot Points after the roll
+ */
+func (r *results) recordRoll(pot uint) {
+	if len(r.history) > 0 {
+		record := &r.history[len(r.history)-1]
+		record.pots = append(record.pots, pot)
+	}
+}
+
+/**
+ * Finishes recording the current round
+ *
+ * @param pot Points when the round ended
+ * @param busted True if the round ended with a 7
+ */
+func (r *results) endRound(pot uint, busted bool) {
+	if len(r.history) > 0 {
+		r.history[len(r.history)-1].pot = pot
+		r.history[len(r.history)-1].busted = busted
+	}
+}
+
+/**
+ * Records a bank in the current round
+ *
+ * @param player Player who banked
+ * @param pts Points they banked
+ */
+func (r *results) recordBank(player Player, pts uint) {
+	if len(r.history) > 0 {
+		record := &r.history[len(r.history)-1]
+		record.banks[player.Name()] = pts
+		record.bankRolls[player.Name()] = len(record.pots)
+	}
+}
+
+/**
+ * Creates the scoreboard of what every player banked each round
+ *
+ * @return Table of rounds × players with the totals as a footer
+ */
+func (r *results) scoreboard() *table.Table {
+	t := new(table.Table)
+	t.Title = i18n.T(i18n.TITLE_SCOREBOARD)
+
+	roundHdr := i18n.T(i18n.HDR_ROUND)
+	potHdr := i18n.T(i18n.HDR_POT)
+	players := r.rankedPlayers()
+
+	t.CreateColumn(roundHdr, table.AUTO, 0)
+	t.CreateColumn(potHdr, table.RIGHT, 0)
+	for _, player := range players {
+		t.CreateColumn(player.Name(), table.AUTO, '\u2716') // ✖
+	}
+
+	// Starting points of handicapped players and late joiners, so the columns add up to the totals
+	start := map[string]any{roundHdr: i18n.T(i18n.CELL_START)}
+	for _, player := range players {
+		if player.start > 0 {
+			start[player.Name()] = player.start
+		}
+	}
+	if len(start) > 1 {
+		t.AddEntry(start)
+	}
+
+	for num, record := range r.history {
+		data := map[string]any{roundHdr: num + 1}
+		if record.busted {
+			data[potHdr] = i18n.T(i18n.CELL_BUSTED_POT, record.pot)
+		} else {
+			data[potHdr] = record.pot
+		}
+		for name, pts := range record.banks {
+			data[name] = pts
+		}
+		t.AddEntry(data)
+	}
+
+	totals := map[string]any{roundHdr: i18n.T(i18n.CELL_TOTAL)}
+	for _, player := range players {
+		totals[player.Name()] = player.pts
+	}
+	t.AddFooter(totals)
+
+	return t
+}
+
+/**
+ * Draws a line chart of each player's total points after every round
+ *
+ * @param height Number of lines the plot area uses, at least MIN_CHART_HEIGHT
+ *
+ * @return The chart with a legend
+ */
+func (r *results) chart(height int) string {
+	height = max(height, MIN_CHART_HEIGHT)
+	players := r.rankedPlayers()
+	if len(r.history) == 0 || len(players) == 0 {
+		return i18n.T(i18n.CELL_NO_ROUNDS) + "\n\r"
+	}
+
+	// Running totals per player after each round
+	totals := make([][]uint, len(players))
+	var maxPts uint = 1
+	for idx, player := range players {
+		total := player.start
+		totals[idx] = make([]uint, len(r.history))
+		for num, record := range r.history {
+			total += record.banks[player.Name()]
+			totals[idx][num] = total
+			maxPts = max(maxPts, total)
+		}
+	}
+
+	const colsPerRound = 4
+	width := len(r.history) * colsPerRound
+	grid := make([][]rune, height)
+	for row := range grid {
+		grid[row] = []rune(strings.Repeat(" ", width))
+	}
+
+	// Gets the row a number of points is plotted at, row 0 is the top
+	rowOf := func(pts float64) int {
+		return height - 1 - int(pts/float64(maxPts)*float64(height-1)+0.5)
+	}
+
+	// Plot the players in reverse so the leaders are drawn on top
+	for idx := len(players) - 1; idx >= 0; idx-- {
+		marker := chartMarkers[idx%len(chartMarkers)]
+		prev := players[idx].start
+		for num, pts := range totals[idx] {
+			// Connect the previous round to this one
+			for step := 1; step < colsPerRound; step++ {
+				between := float64(prev) + (float64(pts)-float64(prev))*float64(step)/colsPerRound
+				grid[rowOf(between)][num*colsPerRound+step-1] = '·'
+			}
+			grid[rowOf(float64(pts))][num*colsPerRound+colsPerRound-1] = marker
+			prev = pts
+		}
+	}
+
+	labelWidth := len(fmt.Sprint(maxPts))
+	buf := bytes.NewBufferString("")
+	for row := range grid {
+		label := ""
+		if row == 0 || row == height/2 || row == height-1 {
+			label = fmt.Sprint(maxPts * uint(height-1-row) / uint(height-1))
+		}
+		fmt.Fprintf(buf, "%*s ┤%s\n\r", labelWidth, label, strings.TrimRight(string(grid[row]), " "))
+	}
+
+	fmt.Fprintf(buf, "%*s └%s\n\r", labelWidth, "", strings.Repeat("─", width))
+	fmt.Fprintf(buf, "%*s  ", labelWidth, "")
+	for num := range r.history {
+		fmt.Fprintf(buf, "%*d", colsPerRound, num+1)
+	}
+	fmt.Fprintf(buf, "  %s\n\r\n\r", i18n.T(i18n.CELL_CHART_AXIS))
+
+	for idx, player := range players {
+		fmt.Fprintf(buf, "%c %s\n\r", chartMarkers[idx%len(chartMarkers)], player.Name())
+	}
+
+	return buf.String()
+}
@@ -0,0 +1,84 @@
+package game
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestScoreboard(t *testing.T) {
+	r := new(results)
+	steady := thresholdAgent{"Steady", 0}
+	greedy := thresholdAgent{"Greedy", 0}
+	r.addPlayer(steady)
+	r.addPlayer(greedy)
+
+	r.startRound()
+	r.playerBanks(steady, 30)
+	r.endRound(45, true)
+	r.unbankAllPlayers()
+
+	r.startRound()
+	r.playerBanks(steady, 80)
+	r.playerBanks(greedy, 200)
+	r.endRound(200, false)
+	r.unbankAllPlayers()
+
+	if len(r.history) != 2 {
+		t.Fatalf("expected 2 rounds recorded, got %d", len(r.history))
+	}
+	if record := r.history[0]; !record.busted || record.pot != 45 || len(record.banks) != 1 || record.banks["Steady"] != 30 {
+		t.Errorf("unexpected first round: %+v", record)
+	}
+	if record := r.history[1]; record.busted || record.banks["Greedy"] != 200 {
+		t.Errorf("unexpected second round: %+v", record)
+	}
+
+	scoreboard := r.scoreboard().String()
+	for _, expected := range []string{"Scoreboard", " Greedy │ Steady", " 45 (7) │      ✖ │     30", " Total │        │    200 │    110"} {
+		if !strings.Contains(scoreboard, expected) {
+			t.Errorf("scoreboard is missing %q:\n%s", expected, scoreboard)
+		}
+	}
+
+	chart := r.chart(CHART_HEIGHT)
+	for _, expected := range []string{"200 ┤", "* Greedy", "o Steady"} {
+		if !strings.Contains(chart, expected) {
+			t.Errorf("chart is missing %q:\n%s", expected, chart)
+		}
+	}
+}
+
+func TestChartNoRounds(t *testing.T) {
+	r := new(results)
+	r.addPlayer(thresholdAgent{"Steady", 0})
+	if chart := r.chart(CHART_HEIGHT); !strings.HasPrefix(chart, "No rounds") {
+		t.Errorf("unexpected chart with no rounds:\n%s", chart)
+	}
+}
+
+func TestChartSmallestHeights(t *testing.T) {
+	r := new(results)
+	steady := thresholdAgent{"Steady", 0}
+	r.addPlayer(steady)
+	r.startRound()
+	r.playerBanks(steady, 30)
+	r.endRound(30, false)
+	r.unbankAllPlayers()
+
+	// Heights below the minimum are drawn at the minimum, with the top and bottom labeled
+	smallest := r.chart(MIN_CHART_HEIGHT)
+	for _, height := range []int{-1, 0, 1} {
+		if chart := r.chart(height); chart != smallest {
+			t.Errorf("height %d:\n%s\nexpected:\n%s", height, chart, smallest)
+		}
+	}
+	for _, expected := range []string{"30 ┤", " 0 ┤"} {
+		if !strings.Contains(smallest, expected) {
+			t.Errorf("chart is missing %q:\n%s", expected, smallest)
+		}
+	}
+
+	if chart := r.chart(3); strings.Count(chart, "┤") != 3 {
+		t.Errorf("expected 3 lines plotted:\n%s", chart)
+	}
+}
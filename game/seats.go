@@ -0,0 +1,335 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Sparhawk96/bank-ais/i18n"
+)
+
+type SeatChange string
+
+const (
+	SEAT_JOIN     SeatChange = "join"     // A player joined after the game started
+	SEAT_FORFEIT  SeatChange = "forfeit"  // A player left and gave up their seat
+	SEAT_STAND_IN SeatChange = "stand-in" // An AI Agent took over the seat of a human who left
+)
+
+/**
+ * Player waiting for the next round to join
+ */
+type joiner struct {
+	player Player
+	pts    uint
+}
+
+/**
+ * Adds a player once the game has started. They enter at the start of the next
+ * round, or right away if the dice haven't been rolled this round.
+ *
+ * @param player Player joining the game
+ * @param startingPoints Points the player starts with
+ *
+ * @return An error if the game isn't being played, the player is nil, another
+ *         player with the same name exists, or the game is played with teams
+ */
+func (g *Game) Join(player Player, startingPoints uint) error {
+	if err := g.checkPlaying(); err != nil {
+		return err
+	} else if player == nil {
+		return errors.New("added nil player")
+	} else if len(g.results.teams) > 0 {
+		return errors.New("players can't join a game played with teams")
+	}
+
+	name := player.Name()
+	if _, exists := g.players[name]; exists {
+		return fmt.Errorf("player already exists with that name: '%s'", name)
+	}
+	for _, waiting := range g.joining {
+		if waiting.player.Name() == name {
+			return fmt.Errorf("player is already joining with that name: '%s'", name)
+		}
+	}
+
+	g.joining = append(g.joining, joiner{player, startingPoints})
+	if len(g.rounds[g.currentRound].rolls) == 0 {
+		g.seatJoiners()
+	}
+	return nil
+}
+
+/**
+ * Gets the players waiting for the next round to join
+ *
+ * @return Names of the players joining
+ */
+func (g *Game) Joining() []string {
+	names := make([]string, 0, len(g.joining))
+	for _, waiting := range g.joining {
+		names = append(names, waiting.player.Name())
+	}
+	return names
+}
+
+/**
+ * Removes a player from the game. They keep their points but rank below
+ * everyone still playing and can't win. The round ends if nobody is left to bank.
+ *
+ * @param name Name of the player leaving
+ *
+ * @return An error if the game isn't being played or the player isn't playing
+ */
+func (g *Game) Forfeit(name string) error {
+	if err := g.forfeit(name); err != nil {
+		return err
+	}
+	g.finishRoundIfAllBanked()
+	return nil
+}
+
+/**
+ * Has an AI Agent take over the seat of a human player who is leaving. The
+ * agent keeps the player's points and decides from the next roll on.
+ *
+ * @param name Name of the human player leaving
+ * @param agent AI Agent taking over, which must have the same name
+ *
+ * @return An error if the game isn't being played, the player isn't a human
+ *         still playing, or the agent can't take over
+ */
+func (g *Game) Replace(name string, agent Player) error {
+	if err := g.checkPlaying(); err != nil {
+		return err
+	}
+
+	player, has := g.players[name]
+	switch {
+	case !has:
+		return fmt.Errorf("no player with that name: '%s'", name)
+	case player.AiAgent():
+		return fmt.Errorf("only human players can be replaced: '%s'", name)
+	case g.results.players[name].eliminated:
+		return fmt.Errorf("player isn't playing: '%s'", name)
+	case agent == nil || !agent.AiAgent():
+		return fmt.Errorf("an AI Agent must take over: '%s'", name)
+	case agent.Name() != name:
+		return fmt.Errorf("AI Agent must take over with the same name: '%s'", agent.Name())
+	}
+
+	g.players[name] = agent
+	for idx := range g.order {
+		if g.order[idx].Name() == name {
+			g.order[idx] = agent
+		}
+	}
+	g.results.standIn(agent, g.currentRound)
+	g.leftSeat(name, SEAT_STAND_IN)
+	return nil
+}
+
+/**
+ * Sets the AI Agent that takes over when a human leaves in the terminal
+ *
+ * @param standIn Creates the agent for the human's name, nil to only let humans forfeit
+ */
+func (g *Game) SetStandIn(standIn func(name string) Player) {
+	g.standIn = standIn
+}
+
+/**
+ * Removes a player from the game without ending the round
+ *
+ * @param name Name of the player leaving
+ *
+ * @return An error if the game isn't being played or the player isn't playing
+ */
+func (g *Game) forfeit(name string) error {
+	if err := g.checkPlaying(); err != nil {
+		return err
+	}
+
+	pn, has := g.results.players[name]
+	if !has {
+		return fmt.Errorf("no player with that name: '%s'", name)
+	} else if pn.eliminated {
+		return fmt.Errorf("player isn't playing: '%s'", name)
+	}
+
+	pn.forfeited = true
+	g.results.eliminate(pn, g.currentRound)
+	g.leftSeat(name, SEAT_FORFEIT)
+	return nil
+}
+
+/**
+ * Updates the game after a player left their seat
+ *
+ * @param name Name of the player who left
+ * @param change How they left
+ */
+func (g *Game) leftSeat(name string, change SeatChange) {
+	g.onlyAI = g.results.humanPlayers == 0
+	g.lastBank = nil // The banks made before aren't the same players anymore
+	if g.host == name {
+		g.host = ""
+	}
+	g.notifySeatChange(name, change)
+}
+
+/**
+ * Adds the players waiting to join at the start of a round
+ */
+func (g *Game) seatJoiners() {
+	for _, waiting := range g.joining {
+		g.players[waiting.player.Name()] = waiting.player
+		g.order = append(g.order, waiting.player)
+		g.results.addPlayer(waiting.player)
+		g.results.join(waiting.player, waiting.pts, g.currentRound)
+		g.onlyAI = g.onlyAI && waiting.player.AiAgent()
+		g.notifySeatChange(waiting.player.Name(), SEAT_JOIN)
+	}
+	g.joining = nil
+}
+
+/**
+ * Notifies the observers that a player joined or left
+ *
+ * @param name Name of the player
+ * @param change How their seat changed
+ */
+func (g *Game) notifySeatChange(name string, change SeatChange) {
+	event := SeatEvent{
+		Round:     g.currentRound,
+		Player:    name,
+		Change:    change,
+		Points:    g.results.players[name].pts,
+		Standings: g.results.standings(),
+	}
+	g.logger.Info("seat change", "round", event.Round+1, "player", name, "change", change, "points", event.Points)
+	g.notify(func(o Observer) {
+		o.OnSeatChange(event)
+	})
+}
+
+/**
+ * Gives a player who joined late their starting points
+ *
+ * @param player Player who joined
+ * @param pts Points they start with
+ * @param round Round they joined in
+ */
+func (r *results) join(player Player, pts uint, round uint8) {
+	pn := r.players[player.Name()]
+	pn.start = pts
+	pn.pts = pts
+	pn.joinedIn = round
+	r.ranking.update(pn.Name(), pn.pts)
+}
+
+/**
+ * Swaps a human player for the AI Agent taking over their seat
+ *
+ * @param agent AI Agent taking over
+ * @param round Round the human left in
+ */
+func (r *results) standIn(agent Player, round uint8) {
+	pn := r.players[agent.Name()]
+	pn.Player = agent
+	pn.standIn = true
+	pn.leftIn = round
+
+	r.humanPlayers--
+	if pn.banked {
+		r.bankedHumanPlayers--
+	}
+}
+
+/**
+ * Dictates if any player joined late or left
+ *
+ * @return True if a seat changed, otherwise false
+ */
+func (r *results) anySeatChanges() bool {
+	for _, pn := range r.players {
+		if pn.joinedIn > 0 || pn.standIn || pn.forfeited {
+			return true
+		}
+	}
+	return false
+}
+
+/**
+ * Dictates if any player was knocked out, rather than forfeiting
+ *
+ * @return True if a player was knocked out, otherwise false
+ */
+func (r *results) anyKnockedOut() bool {
+	for _, pn := range r.eliminated {
+		if !pn.forfeited {
+			return true
+		}
+	}
+	return false
+}
+
+/**
+ * Describes how a player's seat changed for the terminal
+ *
+ * @param pn Player to describe
+ *
+ * @return How the seat changed, empty if it didn't
+ */
+func (pn *playerNode) seat() string {
+	switch {
+	case pn.forfeited:
+		return i18n.T(i18n.CELL_FORFEITED, pn.eliminatedIn+1)
+	case pn.standIn:
+		return i18n.T(i18n.CELL_STAND_IN, pn.leftIn+1)
+	case pn.joinedIn > 0:
+		return i18n.T(i18n.CELL_JOINED, pn.joinedIn+1)
+	}
+	return ""
+}
+
+/**
+ * Prompts for a player joining at the start of the next round
+ */
+func (g *Game) promptJoin() {
+	name := GetInput(i18n.T(i18n.PROMPT_PLAYER_NAME)+" "+PROMPT, false)
+	input := GetInput(i18n.T(i18n.PROMPT_STARTING_PTS)+" "+PROMPT, false)
+
+	var pts uint
+	if input != "" {
+		if _, err := fmt.Sscan(input, &pts); err != nil {
+			printMsg(i18n.PROMPT_INVALID_POINTS, input)
+			return
+		}
+	}
+
+	if err := g.Join(NewHumanPlayer(name), pts); err != nil {
+		printMsg(i18n.MSG_CANT_JOIN, err)
+	} else {
+		printMsg(i18n.MSG_JOINS_NEXT_ROUND, name)
+	}
+}
+
+/**
+ * Prompts for a player leaving, who forfeits or has an AI Agent take over
+ */
+func (g *Game) promptLeave() {
+	name := GetInput(i18n.T(i18n.PROMPT_PLAYER_NAME)+" "+PROMPT, false)
+
+	var err error
+	if g.standIn != nil && i18n.Is(i18n.CMD_STAND_IN, GetInput(i18n.T(i18n.PROMPT_STAND_IN, i18n.Choices(i18n.CMD_STAND_IN))+" "+PROMPT, true)) {
+		err = g.Replace(name, g.standIn(name))
+	} else {
+		err = g.forfeit(name)
+	}
+
+	if err != nil {
+		printMsg(i18n.MSG_CANT_LEAVE, err)
+	} else {
+		printMsg(i18n.MSG_LEFT_GAME, name)
+	}
+}
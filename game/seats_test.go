@@ -0,0 +1,151 @@
+package game
+
+import (
+	"strings"
+	"testing"
+)
+
+/**
+ * Creates a started game of Ann and Bob where the dice were rolled
+ * to a pot of 100, and Timid banks at 50
+ */
+func newSeatGame(t *testing.T) *Game {
+	t.Helper()
+
+	g := NewGame()
+	g.SetSeed(42)
+	g.AddPlayer(NewHumanPlayer("Ann"))
+	g.AddPlayer(NewHumanPlayer("Bob"))
+	g.AddPlayer(thresholdAgent{"Timid", 50})
+	if err := g.Begin(); err != nil {
+		t.Fatal(err)
+	}
+
+	g.rounds[0].points = 100
+	g.rounds[0].rolls = make([]Dice, SAFE_ROLLS+1)
+	return g
+}
+
+func TestJoinAtNextRound(t *testing.T) {
+	g := newSeatGame(t)
+	observer := new(recordingObserver)
+	g.AddObserver(observer)
+
+	if err := g.Join(NewHumanPlayer("Cat"), 80); err != nil {
+		t.Fatal(err)
+	}
+	if joining := g.Joining(); len(joining) != 1 || joining[0] != "Cat" || len(g.State().Players) != 3 {
+		t.Fatalf("expected Cat to wait for the next round: %v", joining)
+	}
+	if err := g.Join(NewHumanPlayer("Cat"), 0); err == nil {
+		t.Error("expected an error joining twice")
+	}
+	if err := g.Join(NewHumanPlayer("Ann"), 0); err == nil {
+		t.Error("expected an error joining with a player's name")
+	}
+
+	g.finishRound(true)
+
+	var cat PlayerDataSnapshot
+	for _, player := range g.State().Players {
+		if player.Name == "Cat" {
+			cat = player
+		}
+	}
+	if cat.Points != 80 || cat.JoinedRound != 1 || len(g.Joining()) != 0 {
+		t.Errorf("expected Cat to join round 2 with 80 points: %+v", cat)
+	}
+	if last, isSeat := observer.events[len(observer.events)-1].(SeatEvent); !isSeat || last.Change != SEAT_JOIN || last.Points != 80 {
+		t.Errorf("expected a join event last, got %+v", observer.events[len(observer.events)-1])
+	}
+
+	if out := g.results.String(); !strings.Contains(out, "Joined round 2") {
+		t.Errorf("expected Cat's seat in the results:\n%s", out)
+	}
+	if out := g.results.scoreboard().String(); !strings.Contains(out, "Start") {
+		t.Errorf("expected Cat's starting points on the scoreboard:\n%s", out)
+	}
+}
+
+func TestJoinErrors(t *testing.T) {
+	g := NewGame()
+	if err := g.Join(NewHumanPlayer("Ann"), 0); err == nil {
+		t.Error("expected an error joining before the game started")
+	}
+
+	g = newTeamGame(t, TEAM_BANK_ALONE)
+	if err := g.Join(NewHumanPlayer("Cat"), 0); err == nil {
+		t.Error("expected an error joining a team game")
+	}
+}
+
+func TestForfeit(t *testing.T) {
+	g := newSeatGame(t)
+	g.BankPlayer("Ann")
+
+	// Timid banked with Ann, so the round ends once Bob leaves
+	if err := g.Forfeit("Bob"); err != nil {
+		t.Fatal(err)
+	}
+	if g.State().CurrentRound != 1 {
+		t.Fatal("expected the round to end with nobody left to bank")
+	}
+	if err := g.Forfeit("Bob"); err == nil {
+		t.Error("expected an error forfeiting twice")
+	}
+
+	players := g.State().Players
+	if last := players[len(players)-1]; last.Name != "Bob" || !last.Forfeited || !last.Eliminated {
+		t.Errorf("expected Bob last after forfeiting: %+v", players)
+	}
+	for _, winner := range g.Winners() {
+		if winner == "Bob" {
+			t.Error("expected Bob to not win after forfeiting")
+		}
+	}
+	if out := g.results.String(); !strings.Contains(out, "Forfeited round 1") || strings.Contains(out, "Knocked Out") {
+		t.Errorf("expected Bob's forfeit in the results:\n%s", out)
+	}
+
+	// The game is over once everyone left
+	g.Forfeit("Ann")
+	g.Forfeit("Timid")
+	if !g.Over() {
+		t.Error("expected the game to end once everyone forfeited")
+	}
+}
+
+func TestReplaceWithAiAgent(t *testing.T) {
+	g := newSeatGame(t)
+	g.BankPlayer("Bob")
+
+	for _, agent := range []Player{nil, NewHumanPlayer("Bob"), thresholdAgent{"Robot", 50}} {
+		if err := g.Replace("Bob", agent); err == nil {
+			t.Errorf("expected an error replacing Bob with %v", agent)
+		}
+	}
+	if err := g.Replace("Timid", thresholdAgent{"Timid", 50}); err == nil {
+		t.Error("expected an error replacing an AI Agent")
+	}
+
+	if err := g.Replace("Bob", thresholdAgent{"Bob", 50}); err != nil {
+		t.Fatal(err)
+	}
+	bob := g.results.getPlayerData(g.players["Bob"])
+	if !bob.AiAgent || !bob.StandIn || !bob.Banked || bob.Points != 100 {
+		t.Errorf("expected the agent to keep Bob's seat: %+v", bob)
+	}
+	if g.results.humanPlayers != 1 || g.results.bankedHumanPlayers != 0 {
+		t.Errorf("expected only Ann to be left to bank: %d of %d", g.results.bankedHumanPlayers, g.results.humanPlayers)
+	}
+
+	g.Replace("Ann", thresholdAgent{"Ann", 50})
+	if !g.onlyAI {
+		t.Error("expected only AI Agents to be playing")
+	}
+	for !g.Over() {
+		if _, err := g.Roll(); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+)
+
+const GAME_NOT_STARTED_ERR_MSG = "game hasn't started"
+const GAME_IS_OVER_ERR_MSG = "game is over"
+
+//////////////////////////////////////////////
+//                                          //
+// Drives a game one action at a time for   //
+// front-ends that aren't the terminal      //
+//                                          //
+//////////////////////////////////////////////
+
+/**
+ * Starts the game without prompting, the game is then played with Roll and BankPlayer
+ *
+ * @return An error if the game is already started
+ */
+func (g *Game) Begin() error {
+	if g.started {
+		return errors.New(GAME_HAS_STARTED_ERR_MSG)
+	} else if err := g.checkTeams(); err != nil {
+		return err
+	} else if err := g.checkMode(); err != nil {
+		return err
+	}
+	g.started = true
+	g.results.startRound()
+	g.logger.Info("game started", "seed", g.seed, "players", len(g.order), "mode", g.mode)
+
+	g.notify(func(o Observer) {
+		o.OnGameStart(GameStartEvent{Seed: g.seed, Players: g.results.standings()})
+	})
+	return nil
+}
+
+/**
+ * Rolls the dice for the current round. AI Agents are then asked to bank, and
+ * the round ends if a 7 is rolled after the safe rolls or every player banked.
+ * A round the host kept open after everyone banked ends first, and the dice
+ * are rolled for the next round.
+ *
+ * @return The roll, empty if ending the open round ended the game, or an error
+ *         if the game hasn't started or is over
+ */
+func (g *Game) Roll() (RollEvent, error) {
+	if err := g.checkPlaying(); err != nil {
+		return RollEvent{}, err
+	}
+
+	if len(g.results.getUnbankedPlayers()) == 0 {
+		g.finishRound(false)
+		if g.over {
+			return RollEvent{}, nil
+		}
+	}
+
+	round := &g.rounds[g.currentRound]
+	dice, keepRolling := g.roll(round)
+	event := RollEvent{
+		Round:      g.currentRound,
+		RollNumber: len(round.rolls),
+		Dice:       dice,
+		Points:     round.points,
+		Busted:     !keepRolling,
+	}
+
+	if !keepRolling {
+		g.finishRound(true)
+	} else {
+		g.askAiAgentsToBank()
+		g.finishRoundIfAllBanked()
+	}
+
+	return event, nil
+}
+
+/**
+ * Banks the current round points for a human player. AI Agents are then
+ * asked to bank again, and the round ends if every player banked. With a host
+ * the round is kept open until the next roll instead, so the last bank can
+ * still be undone.
+ *
+ * @param name Name of the player banking
+ *
+ * @return An error if the player can't bank
+ */
+func (g *Game) BankPlayer(name string) error {
+	if err := g.checkPlaying(); err != nil {
+		return err
+	}
+
+	player, has := g.players[name]
+	switch {
+	case !has:
+		return fmt.Errorf("no player with that name: '%s'", name)
+	case player.AiAgent():
+		return fmt.Errorf("AI Agents bank for themselves: '%s'", name)
+	case g.results.players[name].eliminated:
+		return fmt.Errorf("player was knocked out: '%s'", name)
+	case g.results.playerBanked(player):
+		return fmt.Errorf("player already banked this round: '%s'", name)
+	case len(g.rounds[g.currentRound].rolls) == 0:
+		return errors.New("can't bank before the first roll")
+	}
+
+	g.startBankAction()
+	g.bank(player)
+
+	// Same advantage as the terminal, agents can react to someone banking
+	g.askAiAgentsToBank()
+	g.endBankAction()
+	if g.host == "" {
+		g.finishRoundIfAllBanked()
+	}
+	return nil
+}
+
+/**
+ * Dictates if the game has been started
+ *
+ * @return True if the game has started, otherwise false
+ */
+func (g *Game) Started() bool {
+	return g.started
+}
+
+/**
+ * Dictates if every round has been played
+ *
+ * @return True if the game is over, otherwise false
+ */
+func (g *Game) Over() bool {
+	return g.over
+}
+
+/**
+ * Ends the current round if there is nobody left to bank
+ */
+func (g *Game) finishRoundIfAllBanked() {
+	if len(g.results.getUnbankedPlayers()) == 0 {
+		g.finishRound(false)
+	}
+}
+
+/**
+ * Checks the game is being played
+ *
+ * @return An error if the game hasn't started or is over
+ */
+func (g *Game) checkPlaying() error {
+	if g.frozen != nil {
+		return errors.New("AI Agents can't change the game")
+	} else if !g.started {
+		return errors.New(GAME_NOT_STARTED_ERR_MSG)
+	} else if g.over {
+		return errors.New(GAME_IS_OVER_ERR_MSG)
+	}
+	return nil
+}
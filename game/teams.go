@@ -0,0 +1,270 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Sparhawk96/bank-ais/i18n"
+	"github.com/Sparhawk96/bank-ais/table"
+)
+
+type TeamRule int
+
+const (
+	TEAM_BANK_ALONE    TeamRule = iota // Members bank for themselves
+	TEAM_BANK_TOGETHER                 // One member banking banks the whole team
+)
+
+type team struct {
+	name    string
+	members []*playerNode // In the order they were added
+	pts     uint          // Total points of the members
+}
+
+type TeamDataSnapshot struct {
+	Name    string   `json:"name"`
+	Points  uint     `json:"points"`  // Total points of the members
+	Members []string `json:"members"` // Members in ranking order
+}
+
+/**
+ * Adds a team of players. Once a team is added, the teams' total points rank the
+ * results and every player must be on a team before the game starts.
+ *
+ * @param name Name of the team
+ * @param members Names of the players on the team
+ *
+ * @return An error if the game has started, the team exists, or a member doesn't
+ *         exist or is already on a team
+ */
+func (g *Game) AddTeam(name string, members ...string) error {
+	if g.started {
+		return errors.New(GAME_HAS_STARTED_ERR_MSG)
+	} else if name == "" {
+		return errors.New("team name is required")
+	} else if _, exists := g.results.teams[name]; exists {
+		return fmt.Errorf("team already exists with that name: '%s'", name)
+	} else if len(members) == 0 {
+		return fmt.Errorf("team has no members: '%s'", name)
+	}
+
+	players := make([]Player, 0, len(members))
+	for _, member := range members {
+		player, has := g.players[member]
+		if !has {
+			return fmt.Errorf("no player with that name: '%s'", member)
+		} else if pn := g.results.players[member]; pn.team != nil {
+			return fmt.Errorf("player is already on team '%s': '%s'", pn.team.name, member)
+		}
+		for _, added := range players {
+			if added == player {
+				return fmt.Errorf("player is on the team twice: '%s'", member)
+			}
+		}
+		players = append(players, player)
+	}
+
+	g.results.addTeam(name, players)
+	return nil
+}
+
+/**
+ * Sets how the members of a team bank
+ *
+ * @param rule TEAM_BANK_ALONE or TEAM_BANK_TOGETHER
+ *
+ * @return An error if the game has started
+ */
+func (g *Game) SetTeamRule(rule TeamRule) error {
+	if g.started {
+		return errors.New(GAME_HAS_STARTED_ERR_MSG)
+	}
+	g.teamRule = rule
+	return nil
+}
+
+/**
+ * Checks every player is on a team when playing with teams
+ *
+ * @return An error naming a player without a team
+ */
+func (g *Game) checkTeams() error {
+	if len(g.results.teams) == 0 {
+		return nil
+	}
+	for _, player := range g.order {
+		if g.results.players[player.Name()].team == nil {
+			return fmt.Errorf("player isn't on a team: '%s'", player.Name())
+		}
+	}
+	return nil
+}
+
+/**
+ * Prints which teams won
+ */
+func (g *Game) printWinningTeams() {
+	winners := g.results.teamRanking.playersAtRank(1)
+	if len(winners) == 1 {
+		printMsg(i18n.MSG_TEAM_WON, winners[0])
+		return
+	}
+
+	names := make([]string, len(winners))
+	for idx, winner := range winners {
+		names[idx] = fmt.Sprintf("'%s'", winner)
+	}
+	printMsg(i18n.MSG_TEAMS_TIED, strings.Join(names, ", "))
+}
+
+/**
+ * Gets the teammates who haven't banked yet this round
+ *
+ * @param player Player whose team to check
+ *
+ * @return Unbanked teammates, in the order they were added to the team
+ */
+func (r *results) unbankedTeammates(player Player) []Player {
+	teammates := make([]Player, 0)
+	if pn := r.players[player.Name()]; pn.team != nil {
+		for _, member := range pn.team.members {
+			if member != pn && !member.banked {
+				teammates = append(teammates, member.Player)
+			}
+		}
+	}
+	return teammates
+}
+
+/**
+ * Adds a team to the results
+ *
+ * @param name Name of the team
+ * @param members Players on the team
+ */
+func (r *results) addTeam(name string, members []Player) {
+	if r.teams == nil {
+		r.teams = make(map[string]*team)
+		r.teamRanking = newRanking()
+		r.teamRanking.logger = r.rankingLogger("teams")
+	}
+
+	t := &team{name: name}
+	for _, member := range members {
+		pn := r.players[member.Name()]
+		pn.team = t
+		t.members = append(t.members, pn)
+		t.pts += pn.pts
+	}
+	r.teams[name] = t
+	r.teamRanking.add(name, t.pts)
+}
+
+/**
+ * Gets all of the teams in ranking order
+ *
+ * @return Teams with the most points first, ties in the order they were added
+ */
+func (r *results) rankedTeams() []*team {
+	ranked := make([]*team, 0, len(r.teams))
+	if r.teamRanking != nil {
+		for _, name := range r.teamRanking.players() {
+			ranked = append(ranked, r.teams[name])
+		}
+	}
+	return ranked
+}
+
+/**
+ * Gets the data of every team in ranking order
+ *
+ * @return Snapshot of every team, empty if not playing with teams
+ */
+func (r *results) teamStandings() []TeamDataSnapshot {
+	standings := make([]TeamDataSnapshot, 0, len(r.teams))
+	for _, t := range r.rankedTeams() {
+		snapshot := TeamDataSnapshot{Name: t.name, Points: t.pts}
+		for _, member := range r.rankedMembers(t) {
+			snapshot.Members = append(snapshot.Members, member.Name())
+		}
+		standings = append(standings, snapshot)
+	}
+	return standings
+}
+
+/**
+ * Gets the members of a team in ranking order
+ *
+ * @param t Team to get the members of
+ *
+ * @return Members with the most points first
+ */
+func (r *results) rankedMembers(t *team) []*playerNode {
+	members := make([]*playerNode, 0, len(t.members))
+	for _, name := range r.ranking.players() {
+		if pn := r.players[name]; pn.team == t {
+			members = append(members, pn)
+		}
+	}
+	return members
+}
+
+/**
+ * Creates the standings table grouped by team
+ *
+ * @return Table of every team, the leading teams highlighted
+ */
+func (r *results) teamTable() *table.Table {
+	t := new(table.Table)
+	t.HeaderHighlight = table.Highlight{Bold: true}
+	t.RowSeparators = true
+
+	teamHdr := i18n.T(i18n.HDR_TEAM)
+	teamPointsHdr := i18n.T(i18n.HDR_TEAM_POINTS)
+	playerHdr := i18n.T(i18n.HDR_PLAYERS)
+	bankedHdr := i18n.T(i18n.HDR_BANKED)
+	pointsHdr := i18n.T(i18n.HDR_POINTS)
+
+	t.CreateColumn(teamHdr, table.LEFT, 0)
+	t.CreateColumn(teamPointsHdr, table.RIGHT, 0)
+	t.CreateColumn(playerHdr, table.LEFT, 0)
+	t.CreateColumn(bankedHdr, table.CENTER, 0)
+	t.CreateColumn(pointsHdr, table.RIGHT, 0)
+
+	for _, tm := range r.rankedTeams() {
+		names := ""
+		banked := ""
+		points := ""
+		for idx, member := range r.rankedMembers(tm) {
+			if idx > 0 {
+				names += "\n"
+				banked += "\n"
+				points += "\n"
+			}
+			if member.AiAgent() {
+				names += i18n.T(i18n.CELL_AI, member.Name())
+			} else {
+				names += member.Name()
+			}
+			if member.banked {
+				banked += "✔"
+			}
+			points += fmt.Sprint(member.pts)
+		}
+
+		data := map[string]any{
+			teamHdr:       tm.name,
+			teamPointsHdr: tm.pts,
+			playerHdr:     names,
+			bankedHdr:     banked,
+			pointsHdr:     points,
+		}
+		if r.teamRanking.rank(tm.name) == 1 {
+			t.AddHighlightedEntry(data, table.Highlight{Bold: true})
+		} else {
+			t.AddEntry(data)
+		}
+	}
+	return t
+}
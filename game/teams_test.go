@@ -0,0 +1,145 @@
+package game
+
+import (
+	"strings"
+	"testing"
+)
+
+/**
+ * Creates a game of two teams, a human and an agent on each
+ */
+func newTeamGame(t *testing.T, rule TeamRule) *Game {
+	t.Helper()
+
+	g := NewGame()
+	g.SetSeed(42)
+	g.AddPlayer(NewHumanPlayer("Ann"))
+	g.AddPlayer(thresholdAgent{"Timid", 50})
+	g.AddPlayer(NewHumanPlayer("Bob"))
+	g.AddPlayer(thresholdAgent{"Greedy", 250})
+	g.SetTeamRule(rule)
+
+	if err := g.AddTeam("Red", "Ann", "Timid"); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.AddTeam("Blue", "Bob", "Greedy"); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Begin(); err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+func TestAddTeamErrors(t *testing.T) {
+	g := NewGame()
+	g.AddPlayer(NewHumanPlayer("Ann"))
+	g.AddPlayer(NewHumanPlayer("Bob"))
+	g.AddPlayer(NewHumanPlayer("Cat"))
+
+	for _, tc := range []struct {
+		team    string
+		members []string
+	}{
+		{"", []string{"Ann"}},
+		{"Empty", nil},
+		{"Ghosts", []string{"Nobody"}},
+		{"Twice", []string{"Ann", "Ann"}},
+	} {
+		if err := g.AddTeam(tc.team, tc.members...); err == nil {
+			t.Errorf("expected an error adding team %q %v", tc.team, tc.members)
+		}
+	}
+
+	if err := g.AddTeam("Red", "Ann"); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.AddTeam("Red", "Bob"); err == nil {
+		t.Error("expected an error adding a team twice")
+	}
+	if err := g.AddTeam("Blue", "Ann", "Bob"); err == nil {
+		t.Error("expected an error adding a player to two teams")
+	}
+
+	// Bob and Cat still need a team
+	if err := g.Begin(); err == nil || !strings.Contains(err.Error(), "Bob") {
+		t.Errorf("expected an error for Bob without a team, got %v", err)
+	}
+	g.AddTeam("Blue", "Bob", "Cat")
+	if err := g.Begin(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestTeamsRankByTotal(t *testing.T) {
+	g := newTeamGame(t, TEAM_BANK_ALONE)
+
+	// Bob has the most points but Red has the highest total
+	g.results.playerBanks(g.players["Ann"], 100)
+	g.results.playerBanks(g.players["Timid"], 100)
+	g.results.playerBanks(g.players["Bob"], 150)
+
+	state := g.State()
+	if len(state.Teams) != 2 || state.Teams[0].Name != "Red" || state.Teams[0].Points != 200 || state.Teams[1].Points != 150 {
+		t.Fatalf("unexpected teams: %+v", state.Teams)
+	}
+
+	names := make([]string, 0)
+	for _, player := range state.Players {
+		names = append(names, player.Name+"/"+player.Team)
+	}
+	if got := strings.Join(names, " "); got != "Ann/Red Timid/Red Bob/Blue Greedy/Blue" {
+		t.Errorf("expected players grouped by team, got %s", got)
+	}
+
+	leaders := make([]string, 0)
+	for _, leader := range g.results.leaders() {
+		leaders = append(leaders, leader.Name())
+	}
+	if strings.Join(leaders, " ") != "Ann Timid" {
+		t.Errorf("expected Red's members to lead, got %v", leaders)
+	}
+
+	out := g.results.String()
+	for _, want := range []string{"Team Points", "Red", "200", "Timid (AI)"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in the team standings:\n%s", want, out)
+		}
+	}
+}
+
+func TestTeamBanksTogether(t *testing.T) {
+	g := newTeamGame(t, TEAM_BANK_TOGETHER)
+	g.Roll()
+
+	if err := g.BankPlayer("Bob"); err != nil {
+		t.Fatal(err)
+	}
+
+	state := g.State()
+	for _, player := range state.Players {
+		if banked := player.Team == "Blue"; player.Banked != banked {
+			t.Errorf("expected only Blue to have banked: %+v", player)
+		}
+	}
+	if state.Teams[0].Name != "Blue" || state.Teams[0].Points != 2*state.RoundPoints {
+		t.Errorf("expected Blue to bank the pot twice: %+v", state.Teams)
+	}
+
+	// Greedy banked with Bob so can't bank again
+	if err := g.BankPlayer("Greedy"); err == nil {
+		t.Error("expected an error banking for an agent")
+	}
+}
+
+func TestTeamBanksAlone(t *testing.T) {
+	g := newTeamGame(t, TEAM_BANK_ALONE)
+	g.Roll()
+	g.BankPlayer("Bob")
+
+	for _, player := range g.State().Players {
+		if player.Name == "Greedy" && player.Banked {
+			t.Error("Greedy shouldn't bank with Bob")
+		}
+	}
+}
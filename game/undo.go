@@ -0,0 +1,205 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Sparhawk96/bank-ais/i18n"
+	"github.com/Sparhawk96/bank-ais/table"
+)
+
+/**
+ * Banks made by one bank action, the humans who banked and every
+ * player who banked with them or in reaction to them
+ */
+type bankAction struct {
+	round uint8
+	rolls int
+	banks []BankEvent // In the order they were made
+}
+
+/**
+ * Bank action reversed by the host
+ */
+type Undo struct {
+	Round      uint8       `json:"round"`
+	RollNumber int         `json:"rollNumber"`
+	Host       string      `json:"host"`
+	Banks      []BankEvent `json:"banks"` // Banks reversed, in the order they were made
+}
+
+/**
+ * Chooses the human player who can undo banks
+ *
+ * @param name Name of the host
+ *
+ * @return An error if the game has started or the host isn't a human player
+ */
+func (g *Game) SetHost(name string) error {
+	if g.started {
+		return errors.New(GAME_HAS_STARTED_ERR_MSG)
+	}
+
+	player, has := g.players[name]
+	if !has {
+		return fmt.Errorf("no player with that name: '%s'", name)
+	} else if player.AiAgent() {
+		return fmt.Errorf("AI Agents can't host: '%s'", name)
+	}
+	g.host = name
+	return nil
+}
+
+/**
+ * Gets the human player who can undo banks
+ *
+ * @return Name of the host, empty if there isn't one
+ */
+func (g *Game) Host() string {
+	return g.host
+}
+
+/**
+ * Reverses the last bank action, as long as the dice haven't been rolled and
+ * the round hasn't ended since. Every player who banked in the action is
+ * unbanked, including the AI Agents who banked in reaction.
+ *
+ * @param name Name of the player undoing the bank, which must be the host
+ *
+ * @return What was undone, or an error if the player isn't the host or there is nothing to undo
+ */
+func (g *Game) Undo(name string) (UndoEvent, error) {
+	if err := g.checkPlaying(); err != nil {
+		return UndoEvent{}, err
+	}
+
+	switch {
+	case g.host == "":
+		return UndoEvent{}, errors.New("no host was chosen to undo banks")
+	case name != g.host:
+		return UndoEvent{}, fmt.Errorf("only the host can undo banks: '%s'", name)
+	case g.lastBank == nil:
+		return UndoEvent{}, errors.New("no bank to undo since the last roll")
+	}
+
+	action := g.lastBank
+	g.lastBank = nil
+	for idx := len(action.banks) - 1; idx >= 0; idx-- {
+		bank := action.banks[idx]
+		g.results.playerUnbanks(g.players[bank.Player], bank.Points)
+	}
+
+	undo := Undo{Round: action.round, RollNumber: action.rolls, Host: name, Banks: action.banks}
+	g.results.undos = append(g.results.undos, undo)
+
+	g.logger.Info("undo", "round", undo.Round+1, "roll", undo.RollNumber, "host", name, "banks", undo.banks())
+
+	event := UndoEvent{Undo: undo, Standings: g.results.standings()}
+	g.notify(func(o Observer) {
+		o.OnUndo(event)
+	})
+	return event, nil
+}
+
+/**
+ * Gets every bank action the host undid
+ *
+ * @return Copy of the undo log
+ */
+func (g *Game) Undos() []Undo {
+	if g.frozen != nil {
+		return append([]Undo(nil), g.frozen.undos...)
+	}
+	return append([]Undo(nil), g.results.undos...)
+}
+
+/**
+ * Starts recording the banks of a bank action so it can be undone
+ */
+func (g *Game) startBankAction() {
+	round := &g.rounds[g.currentRound]
+	g.action = &bankAction{round: g.currentRound, rolls: len(round.rolls)}
+}
+
+/**
+ * Finishes recording a bank action, which becomes the one undone if anyone banked
+ */
+func (g *Game) endBankAction() {
+	if g.action != nil && len(g.action.banks) > 0 {
+		g.lastBank = g.action
+	}
+	g.action = nil
+}
+
+/**
+ * Reverses a player's bank, restoring their score and ranking
+ *
+ * @param player Player who banked
+ * @param pts Points they banked
+ */
+func (r *results) playerUnbanks(player Player, pts uint) {
+	pn := r.players[player.Name()]
+	pn.pts -= pts
+	pn.banked = false
+
+	if !pn.AiAgent() {
+		r.bankedHumanPlayers--
+	}
+
+	r.ranking.update(pn.Name(), pn.pts)
+	if pn.team != nil {
+		pn.team.pts -= pts
+		r.teamRanking.update(pn.team.name, pn.team.pts)
+	}
+
+	if len(r.history) > 0 {
+		record := &r.history[len(r.history)-1]
+		delete(record.banks, player.Name())
+		delete(record.bankRolls, player.Name())
+	}
+}
+
+/**
+ * Lists the banks that were undone
+ *
+ * @return Each player and the points they banked
+ */
+func (undo Undo) banks() string {
+	banks := make([]string, 0, len(undo.Banks))
+	for _, bank := range undo.Banks {
+		banks = append(banks, fmt.Sprintf("%s (%d)", bank.Player, bank.Points))
+	}
+	return strings.Join(banks, ", ")
+}
+
+/**
+ * Creates a table of the undo log
+ *
+ * @param undos Undos to list
+ *
+ * @return The undo table
+ */
+func undoTable(undos []Undo) *table.Table {
+	roundHdr := i18n.T(i18n.HDR_ROUND)
+	rollHdr := i18n.T(i18n.HDR_ROLL)
+	hostHdr := i18n.T(i18n.HDR_HOST)
+	banksHdr := i18n.T(i18n.HDR_BANKS_UNDONE)
+
+	t := new(table.Table)
+	t.Title = i18n.T(i18n.TITLE_UNDONE_BANKS)
+	t.CreateColumn(roundHdr, table.RIGHT, 0)
+	t.CreateColumn(rollHdr, table.RIGHT, 0)
+	t.CreateColumn(hostHdr, table.LEFT, 0)
+	t.CreateColumn(banksHdr, table.LEFT, 0)
+
+	for _, undo := range undos {
+		t.AddEntry(map[string]any{
+			roundHdr: int(undo.Round) + 1,
+			rollHdr:  undo.RollNumber,
+			hostHdr:  undo.Host,
+			banksHdr: undo.banks(),
+		})
+	}
+	return t
+}
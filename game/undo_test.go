@@ -0,0 +1,161 @@
+package game
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+/**
+ * Creates a started game hosted by Ann, where Copy banks once anyone banks
+ */
+func newHostedGame(t *testing.T) *Game {
+	t.Helper()
+
+	g := NewGame()
+	g.SetSeed(42)
+	g.AddPlayer(NewHumanPlayer("Ann"))
+	g.AddPlayer(NewHumanPlayer("Bob"))
+	g.AddPlayer(NewHumanPlayer("Cat"), Handicap{StartingPoints: 50})
+	g.AddPlayer(followerAgent{"Copy"})
+	if err := g.SetHost("Ann"); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Begin(); err != nil {
+		t.Fatal(err)
+	}
+
+	g.rounds[0].points = 100
+	g.rounds[0].rolls = make([]Dice, SAFE_ROLLS+1)
+	return g
+}
+
+func TestUndoRestoresResults(t *testing.T) {
+	g := newHostedGame(t)
+	observer := new(recordingObserver)
+	g.AddObserver(observer)
+
+	before := g.State()
+	if err := g.BankPlayer("Bob"); err != nil {
+		t.Fatal(err)
+	}
+	if after := g.State(); after.Players[0].Name != "Bob" || !after.Players[1].Banked {
+		t.Fatalf("expected Bob to lead and Copy to follow: %+v", after.Players)
+	}
+
+	if _, err := g.Undo("Bob"); err == nil {
+		t.Error("expected an error undoing as someone other than the host")
+	}
+	undo, err := g.Undo("Ann")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if after := g.State(); !reflect.DeepEqual(before, after) {
+		t.Errorf("expected the results to be restored\nbefore: %+v\nafter:  %+v", before, after)
+	}
+	if len(g.results.history[0].banks) != 0 || g.results.bankedHumanPlayers != 0 {
+		t.Errorf("expected the banks to be removed from the round: %+v", g.results.history[0])
+	}
+
+	if len(undo.Banks) != 2 || undo.Banks[0].Player != "Bob" || undo.Banks[1].Player != "Copy" {
+		t.Errorf("expected Bob's bank and Copy's reaction undone: %+v", undo.Banks)
+	}
+	if last, isUndo := observer.events[len(observer.events)-1].(UndoEvent); !isUndo || last.Host != "Ann" {
+		t.Errorf("expected an undo event last, got %+v", observer.events)
+	}
+	if undos := g.Report().Undos; len(undos) != 1 || !strings.Contains(g.Report().Markdown(), "Undone Banks") {
+		t.Errorf("expected the undo in the report: %+v", undos)
+	}
+
+	// Only the last bank action can be undone
+	if _, err := g.Undo("Ann"); err == nil {
+		t.Error("expected an error undoing twice")
+	}
+}
+
+func TestUndoBeforeNextRoll(t *testing.T) {
+	g := newHostedGame(t)
+	g.BankPlayer("Bob")
+	g.BankPlayer("Cat")
+
+	// Only Cat's bank is undone
+	undo, err := g.Undo("Ann")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(undo.Banks) != 1 || undo.Banks[0].Player != "Cat" || g.results.players["Cat"].pts != 50 {
+		t.Errorf("expected only Cat's bank undone: %+v", undo.Banks)
+	}
+	if !g.results.players["Bob"].banked {
+		t.Error("expected Bob to stay banked")
+	}
+
+	g.BankPlayer("Cat")
+	if _, err := g.Roll(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := g.Undo("Ann"); err == nil {
+		t.Error("expected an error undoing after the next roll")
+	}
+}
+
+func TestUndoLastBankOfRound(t *testing.T) {
+	g := newHostedGame(t)
+	for _, name := range []string{"Bob", "Cat", "Ann"} {
+		if err := g.BankPlayer(name); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The round is kept open for the host until the next roll
+	if g.currentRound != 0 {
+		t.Fatalf("expected the round to stay open, in round %d", g.currentRound+1)
+	}
+	if undo, err := g.Undo("Ann"); err != nil || len(undo.Banks) != 1 || undo.Banks[0].Player != "Ann" {
+		t.Fatalf("expected Ann's bank undone: %+v %v", undo.Banks, err)
+	}
+
+	g.BankPlayer("Ann")
+	roll, err := g.Roll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g.currentRound != 1 || roll.Round != 1 || roll.RollNumber != 1 {
+		t.Errorf("expected the first roll of the next round, got %+v", roll)
+	}
+	if pts := g.results.players["Ann"].pts; pts != 100 {
+		t.Errorf("expected Ann to keep the bank, got %d", pts)
+	}
+}
+
+func TestRollEndsLastOpenRound(t *testing.T) {
+	g := newHostedGame(t)
+	g.mode.Rounds = 1
+	for _, name := range []string{"Ann", "Bob", "Cat"} {
+		g.BankPlayer(name)
+	}
+
+	roll, err := g.Roll()
+	if err != nil || !g.Over() || roll.RollNumber != 0 {
+		t.Errorf("expected the game to end without rolling, got %+v %v", roll, err)
+	}
+}
+
+func TestSetHostErrors(t *testing.T) {
+	g := NewGame()
+	g.AddPlayer(NewHumanPlayer("Ann"))
+	g.AddPlayer(thresholdAgent{"Timid", 50})
+
+	if err := g.SetHost("Nobody"); err == nil {
+		t.Error("expected an error for a host who isn't playing")
+	}
+	if err := g.SetHost("Timid"); err == nil {
+		t.Error("expected an error for an AI Agent host")
+	}
+
+	g.Begin()
+	if _, err := g.Undo("Ann"); err == nil {
+		t.Error("expected an error undoing without a host")
+	}
+}
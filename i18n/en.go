@@ -0,0 +1,158 @@
+package i18n
+
+var english = Locale{
+	Name: "English",
+	Messages: map[Key]string{
+		PROMPT_MAIN:           "Enter %s for help",
+		PROMPT_BANK:           "Enter %s to submit",
+		PROMPT_PLAYER_NAME:    "Enter Player Name",
+		PROMPT_HOST_NAME:      "Enter Host Name",
+		PROMPT_STARTING_PTS:   "Enter Starting Points",
+		PROMPT_STAND_IN:       "Enter %s for an AI Agent to take over, otherwise they forfeit",
+		PROMPT_ADD_AI_AGENTS:  "Add AI Agents (%s/%s)",
+		PROMPT_STOP_ADDING:    "Enter %s to stop adding players.",
+		PROMPT_INVALID_INPUT:  "Invalid Input: '%s'",
+		PROMPT_INVALID_PLAYER: "Invalid Player Name or Number: %s",
+		PROMPT_INVALID_POINTS: "Invalid Starting Points: '%s'",
+
+		MENU_ACTION:       "Action",
+		MENU_COMMANDS_HDR: "Commands",
+		MENU_DESCRIPTION:  "Description",
+		MENU_ENTER:        "enter",
+		WORD_OR:           "%s or %s",
+
+		MenuAction(CMD_POINTS):          "Player Points",
+		MenuDescription(CMD_POINTS):     "Prints the current player points",
+		MenuAction(CMD_BANK):            "Bank Points",
+		MenuDescription(CMD_BANK):       "Enables players to bank the current points",
+		MenuAction(CMD_SCOREBOARD):      "Scoreboard",
+		MenuDescription(CMD_SCOREBOARD): "Prints the points banked each round and a chart of the totals",
+		MenuAction(CMD_UNDO):            "Undo Bank",
+		MenuDescription(CMD_UNDO):       "Lets the host undo the last bank before the next roll",
+		MenuAction(CMD_JOIN):            "Join Game",
+		MenuDescription(CMD_JOIN):       "Adds a player at the start of the next round",
+		MenuAction(CMD_LEAVE):           "Leave Game",
+		MenuDescription(CMD_LEAVE):      "A player forfeits or has an AI Agent take over",
+		MenuAction(CMD_ROLL):            "Roll Dice",
+		MenuDescription(CMD_ROLL):       "Keep going and roll the dice",
+		MenuAction(CMD_HELP):            "Help",
+		MenuDescription(CMD_HELP):       "Prints this menu",
+
+		MSG_STARTING_GAME:     "Starting Game ...",
+		MSG_ROUND_OF:          "Round %d of %d",
+		MSG_ROUND_TARGET:      "Round %d, first to %d",
+		MSG_ROUND_ELIMINATION: "Round %d, elimination in %d",
+		MSG_ROUND_STARTING:    "### Starting %s ###",
+		MSG_ROUND_DONE:        "Round %d done!",
+		MSG_CURRENT_POINTS:    "Current Points: %d",
+		MSG_ROLL_NUMBER:       "Roll Number: %d",
+		MSG_AI_BANKED:         "AI Agent '%s' banked!",
+		MSG_ALL_HUMANS_BANKED: "All human players have banked.",
+		MSG_KNOCKED_OUT:       "Player '%s' was knocked out!",
+		MSG_CANT_UNDO:         "Can't undo: %s",
+		MSG_UNDONE:            "Host '%s' undid the banks of %s",
+		MSG_CANT_JOIN:         "Can't join: %s",
+		MSG_JOINS_NEXT_ROUND:  "Player '%s' joins at the start of the next round.",
+		MSG_CANT_LEAVE:        "Can't leave: %s",
+		MSG_LEFT_GAME:         "Player '%s' left the game.",
+		MSG_NOBODY_PLAYED:     "Nobody played!",
+		MSG_PLAYER_WON:        "Player '%s' won!",
+		MSG_PLAYERS_TIED:      "Players %s tied!",
+		MSG_TEAM_WON:          "Team '%s' won!",
+		MSG_TEAMS_TIED:        "Teams %s tied!",
+		MSG_MATCH_WON:         "Match won by '%s'!",
+		MSG_PLAYER_NOT_ADDED:  "Player already exists with that name, or their handicap isn't valid.",
+		MSG_ADDING_AI_AGENTS:  "Adding in AI Agents ...",
+		MSG_REPORT_WRITTEN:    "Report written to '%s'",
+		MSG_HOSTING_GAMES:     "Hosting games on '%s' ...",
+		MSG_HOSTING_ARENA:     "Hosting the arena on '%s' ...",
+
+		TITLE_GAME_MODE:     "Game Mode",
+		TITLE_SCOREBOARD:    "Scoreboard",
+		TITLE_GAME_REPORT:   "Game Report",
+		TITLE_PLAYER_REPORT: "Player Report",
+		TITLE_REPORT:        "Bank Game Report",
+		TITLE_MATCH:         "Match, Best of %d (%d played)",
+		TITLE_FAULTS:        "Faults",
+		TITLE_UNDONE_BANKS:  "Undone Banks",
+		TITLE_LEADERBOARD:   "Arena Leaderboard",
+
+		HDR_PLAYER:        "Player",
+		HDR_PLAYERS:       "Players",
+		HDR_AI_AGENT:      "AI Agent",
+		HDR_AGENT:         "Agent",
+		HDR_BANKED:        "Banked",
+		HDR_POINTS:        "Points",
+		HDR_TOTAL_POINTS:  "Total Points",
+		HDR_FAULTS:        "Faults",
+		HDR_FAULT:         "Fault",
+		HDR_DETAIL:        "Detail",
+		HDR_KNOCKED_OUT:   "Knocked Out",
+		HDR_HANDICAP:      "Handicap",
+		HDR_SEAT:          "Seat",
+		HDR_TEAM:          "Team",
+		HDR_TEAM_POINTS:   "Team Points",
+		HDR_ROUND:         "Round",
+		HDR_ROLL:          "Roll",
+		HDR_POT:           "Pot",
+		HDR_RULE:          "Rule",
+		HDR_STAT:          "Stat",
+		HDR_VALUE:         "Value",
+		HDR_BANKS:         "Banks",
+		HDR_AVG_BANK:      "Avg Bank",
+		HDR_LEFT_ON_TABLE: "Left on Table",
+		HDR_ROLL_EARLIER:  "1 Roll Earlier",
+		HDR_ROLL_LATER:    "1 Roll Later",
+		HDR_HOST:          "Host",
+		HDR_BANKS_UNDONE:  "Banks Undone",
+		HDR_WINS:          "Wins",
+		HDR_GAMES:         "Games",
+		HDR_RANK:          "#",
+
+		CELL_DISQUALIFIED:   "%s, disqualified",
+		CELL_KNOCKED_OUT_IN: "Round %d",
+		CELL_FORFEITED:      "Forfeited round %d",
+		CELL_STAND_IN:       "AI since round %d",
+		CELL_JOINED:         "Joined round %d",
+		CELL_AI:             "%s (AI)",
+		CELL_FIRST_TO:       "First To",
+		CELL_ELIMINATION:    "Elimination",
+		CELL_EVERY_ROUNDS:   "Every %d rounds",
+		CELL_MOST_ROUNDS:    "Most Rounds",
+		CELL_START:          "Start",
+		CELL_TOTAL:          "Total",
+		CELL_BUSTED_POT:     "%d (7)",
+		CELL_ROUNDS:         "Rounds",
+		CELL_LONGEST_ROUND:  "Longest Round",
+		CELL_ROUND_ROLLS:    "Round %d (%d rolls)",
+		CELL_HIGHEST_POT:    "Highest Pot",
+		CELL_POT_IN_ROUND:   "%d in Round %d",
+		CELL_SEVENS:         "7s Rolled",
+		CELL_DOUBLES:        "Doubles Rolled",
+		CELL_UNKNOWN:        "[%d unknown]",
+		CELL_FAULT_DQ:       "%d DQ",
+		CELL_FAULT_TIMEOUT:  "Timeout",
+		CELL_FAULT_PANIC:    "Panic",
+		CELL_FAULT_INVALID:  "Invalid response",
+		CELL_HANDICAP_PTS:   "+%d pts",
+		CELL_SAFE_ROLL:      "+1 safe roll",
+		CELL_SAFE_ROLLS:     "+%d safe rolls",
+		CELL_NO_ROUNDS:      "No rounds played yet.",
+		CELL_CHART_AXIS:     "(Round)",
+	},
+	Commands: map[Command][]string{
+		CMD_HELP:       {"?", "help", "h"},
+		CMD_POINTS:     {"p", "points", "pp", "print points", "player points"},
+		CMD_BANK:       {"b", "bank", "pb", "player bank", "players bank", "bp", "bank points"},
+		CMD_SCOREBOARD: {"s", "scores", "scoreboard", "sb"},
+		CMD_UNDO:       {"u", "undo", "undo bank"},
+		CMD_JOIN:       {"j", "join"},
+		CMD_LEAVE:      {"l", "leave", "forfeit"},
+		CMD_ROLL:       {"", "r", "roll", "rd", "roll dice"},
+
+		CMD_DONE:     {"d", "done", "submit"},
+		CMD_YES:      {"y", "yes"},
+		CMD_NO:       {"n", "no"},
+		CMD_STAND_IN: {"a"},
+	},
+}
@@ -0,0 +1,158 @@
+package i18n
+
+var spanish = Locale{
+	Name: "Español",
+	Messages: map[Key]string{
+		PROMPT_MAIN:           "Introduce %s para ver la ayuda",
+		PROMPT_BANK:           "Introduce %s para enviar",
+		PROMPT_PLAYER_NAME:    "Nombre del jugador",
+		PROMPT_HOST_NAME:      "Nombre del anfitrión",
+		PROMPT_STARTING_PTS:   "Puntos iniciales",
+		PROMPT_STAND_IN:       "Introduce %s para que un Agente IA ocupe su lugar, si no se rinde",
+		PROMPT_ADD_AI_AGENTS:  "¿Añadir Agentes IA? (%s/%s)",
+		PROMPT_STOP_ADDING:    "Introduce %s para dejar de añadir jugadores.",
+		PROMPT_INVALID_INPUT:  "Entrada no válida: '%s'",
+		PROMPT_INVALID_PLAYER: "Nombre o número de jugador no válido: %s",
+		PROMPT_INVALID_POINTS: "Puntos iniciales no válidos: '%s'",
+
+		MENU_ACTION:       "Acción",
+		MENU_COMMANDS_HDR: "Comandos",
+		MENU_DESCRIPTION:  "Descripción",
+		MENU_ENTER:        "intro",
+		WORD_OR:           "%s o %s",
+
+		MenuAction(CMD_POINTS):          "Puntos",
+		MenuDescription(CMD_POINTS):     "Muestra los puntos actuales de los jugadores",
+		MenuAction(CMD_BANK):            "Guardar Puntos",
+		MenuDescription(CMD_BANK):       "Permite a los jugadores guardar los puntos de la ronda",
+		MenuAction(CMD_SCOREBOARD):      "Marcador",
+		MenuDescription(CMD_SCOREBOARD): "Muestra los puntos guardados en cada ronda y un gráfico de los totales",
+		MenuAction(CMD_UNDO):            "Deshacer",
+		MenuDescription(CMD_UNDO):       "El anfitrión deshace el último guardado antes de la siguiente tirada",
+		MenuAction(CMD_JOIN):            "Unirse",
+		MenuDescription(CMD_JOIN):       "Añade un jugador al empezar la siguiente ronda",
+		MenuAction(CMD_LEAVE):           "Abandonar",
+		MenuDescription(CMD_LEAVE):      "Un jugador se rinde o un Agente IA ocupa su lugar",
+		MenuAction(CMD_ROLL):            "Tirar Dados",
+		MenuDescription(CMD_ROLL):       "Sigue jugando y tira los dados",
+		MenuAction(CMD_HELP):            "Ayuda",
+		MenuDescription(CMD_HELP):       "Muestra este menú",
+
+		MSG_STARTING_GAME:     "Empezando la partida ...",
+		MSG_ROUND_OF:          "Ronda %d de %d",
+		MSG_ROUND_TARGET:      "Ronda %d, gana el primero en llegar a %d",
+		MSG_ROUND_ELIMINATION: "Ronda %d, eliminación en %d",
+		MSG_ROUND_STARTING:    "### Empieza la %s ###",
+		MSG_ROUND_DONE:        "¡Ronda %d terminada!",
+		MSG_CURRENT_POINTS:    "Puntos actuales: %d",
+		MSG_ROLL_NUMBER:       "Tirada número: %d",
+		MSG_AI_BANKED:         "¡El Agente IA '%s' guardó!",
+		MSG_ALL_HUMANS_BANKED: "Todos los jugadores humanos han guardado.",
+		MSG_KNOCKED_OUT:       "¡El jugador '%s' fue eliminado!",
+		MSG_CANT_UNDO:         "No se puede deshacer: %s",
+		MSG_UNDONE:            "El anfitrión '%s' deshizo los guardados de %s",
+		MSG_CANT_JOIN:         "No se puede unir: %s",
+		MSG_JOINS_NEXT_ROUND:  "El jugador '%s' se une al empezar la siguiente ronda.",
+		MSG_CANT_LEAVE:        "No puede abandonar: %s",
+		MSG_LEFT_GAME:         "El jugador '%s' abandonó la partida.",
+		MSG_NOBODY_PLAYED:     "¡Nadie jugó!",
+		MSG_PLAYER_WON:        "¡El jugador '%s' ganó!",
+		MSG_PLAYERS_TIED:      "¡Los jugadores %s empataron!",
+		MSG_TEAM_WON:          "¡El equipo '%s' ganó!",
+		MSG_TEAMS_TIED:        "¡Los equipos %s empataron!",
+		MSG_MATCH_WON:         "¡'%s' ganó el encuentro!",
+		MSG_PLAYER_NOT_ADDED:  "Ya existe un jugador con ese nombre, o su hándicap no es válido.",
+		MSG_ADDING_AI_AGENTS:  "Añadiendo Agentes IA ...",
+		MSG_REPORT_WRITTEN:    "Informe guardado en '%s'",
+		MSG_HOSTING_GAMES:     "Alojando partidas en '%s' ...",
+		MSG_HOSTING_ARENA:     "Alojando la arena en '%s' ...",
+
+		TITLE_GAME_MODE:     "Modo de Juego",
+		TITLE_SCOREBOARD:    "Marcador",
+		TITLE_GAME_REPORT:   "Informe de la Partida",
+		TITLE_PLAYER_REPORT: "Informe de los Jugadores",
+		TITLE_REPORT:        "Informe de la Partida de Bank",
+		TITLE_MATCH:         "Encuentro, al Mejor de %d (%d jugadas)",
+		TITLE_FAULTS:        "Faltas",
+		TITLE_UNDONE_BANKS:  "Guardados Deshechos",
+		TITLE_LEADERBOARD:   "Clasificación de la Arena",
+
+		HDR_PLAYER:        "Jugador",
+		HDR_PLAYERS:       "Jugadores",
+		HDR_AI_AGENT:      "Agente IA",
+		HDR_AGENT:         "Agente",
+		HDR_BANKED:        "Guardó",
+		HDR_POINTS:        "Puntos",
+		HDR_TOTAL_POINTS:  "Puntos Totales",
+		HDR_FAULTS:        "Faltas",
+		HDR_FAULT:         "Falta",
+		HDR_DETAIL:        "Detalle",
+		HDR_KNOCKED_OUT:   "Eliminado",
+		HDR_HANDICAP:      "Hándicap",
+		HDR_SEAT:          "Asiento",
+		HDR_TEAM:          "Equipo",
+		HDR_TEAM_POINTS:   "Puntos del Equipo",
+		HDR_ROUND:         "Ronda",
+		HDR_ROLL:          "Tirada",
+		HDR_POT:           "Bote",
+		HDR_RULE:          "Regla",
+		HDR_STAT:          "Estadística",
+		HDR_VALUE:         "Valor",
+		HDR_BANKS:         "Guardados",
+		HDR_AVG_BANK:      "Media Guardada",
+		HDR_LEFT_ON_TABLE: "Dejado en la Mesa",
+		HDR_ROLL_EARLIER:  "1 Tirada Antes",
+		HDR_ROLL_LATER:    "1 Tirada Después",
+		HDR_HOST:          "Anfitrión",
+		HDR_BANKS_UNDONE:  "Guardados Deshechos",
+		HDR_WINS:          "Victorias",
+		HDR_GAMES:         "Partidas",
+		HDR_RANK:          "#",
+
+		CELL_DISQUALIFIED:   "%s, descalificado",
+		CELL_KNOCKED_OUT_IN: "Ronda %d",
+		CELL_FORFEITED:      "Se rindió en la ronda %d",
+		CELL_STAND_IN:       "IA desde la ronda %d",
+		CELL_JOINED:         "Se unió en la ronda %d",
+		CELL_AI:             "%s (IA)",
+		CELL_FIRST_TO:       "Primero en Llegar a",
+		CELL_ELIMINATION:    "Eliminación",
+		CELL_EVERY_ROUNDS:   "Cada %d rondas",
+		CELL_MOST_ROUNDS:    "Rondas Máximas",
+		CELL_START:          "Inicio",
+		CELL_TOTAL:          "Total",
+		CELL_BUSTED_POT:     "%d (7)",
+		CELL_ROUNDS:         "Rondas",
+		CELL_LONGEST_ROUND:  "Ronda Más Larga",
+		CELL_ROUND_ROLLS:    "Ronda %d (%d tiradas)",
+		CELL_HIGHEST_POT:    "Bote Más Alto",
+		CELL_POT_IN_ROUND:   "%d en la Ronda %d",
+		CELL_SEVENS:         "7 Sacados",
+		CELL_DOUBLES:        "Dobles Sacados",
+		CELL_UNKNOWN:        "[%d desconocidos]",
+		CELL_FAULT_DQ:       "%d DESC",
+		CELL_FAULT_TIMEOUT:  "Tiempo agotado",
+		CELL_FAULT_PANIC:    "Pánico",
+		CELL_FAULT_INVALID:  "Respuesta no válida",
+		CELL_HANDICAP_PTS:   "+%d ptos",
+		CELL_SAFE_ROLL:      "+1 tirada segura",
+		CELL_SAFE_ROLLS:     "+%d tiradas seguras",
+		CELL_NO_ROUNDS:      "Todavía no se ha jugado ninguna ronda.",
+		CELL_CHART_AXIS:     "(Ronda)",
+	},
+	Commands: map[Command][]string{
+		CMD_HELP:       {"?", "ayuda"},
+		CMD_POINTS:     {"p", "puntos"},
+		CMD_BANK:       {"g", "guardar"},
+		CMD_SCOREBOARD: {"m", "marcador"},
+		CMD_UNDO:       {"d", "deshacer"},
+		CMD_JOIN:       {"u", "unirse"},
+		CMD_LEAVE:      {"a", "abandonar", "rendirse"},
+		CMD_ROLL:       {"", "t", "tirar", "tirar dados"},
+
+		CMD_DONE:     {"l", "listo", "enviar"},
+		CMD_YES:      {"s", "sí", "si"},
+		CMD_NO:       {"n", "no"},
+		CMD_STAND_IN: {"a"},
+	},
+}
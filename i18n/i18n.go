@@ -0,0 +1,186 @@
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Locale every message is written in first, and falls back to
+const DEFAULT_LOCALE = "en"
+
+// Environment variable that chooses the locale when no flag is given
+const LOCALE_ENV = "BANK_LANG"
+
+/**
+ * Identifies a message shown to the players
+ */
+type Key string
+
+/**
+ * Identifies a command the players can type
+ */
+type Command string
+
+/**
+ * Messages and command aliases of one language
+ */
+type Locale struct {
+	Name     string               // Name of the language in the language itself
+	Messages map[Key]string       // Messages, which may be fmt format strings
+	Commands map[Command][]string // Lower case aliases of each command, the first one is shown first
+}
+
+var locales = map[string]*Locale{
+	"en": &english,
+	"es": &spanish,
+}
+
+var current = locales[DEFAULT_LOCALE]
+
+/**
+ * Gets the locales that can be used
+ *
+ * @return Tags of the locales, sorted
+ */
+func Locales() []string {
+	tags := make([]string, 0, len(locales))
+	for tag := range locales {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+/**
+ * Chooses the locale every message is shown in
+ *
+ * @param tag Tag of the locale such as 'en' or 'es'
+ *
+ * @return An error if there isn't a locale with the tag, or the keymap
+ *         would make commands share an alias in it
+ */
+func Use(tag string) error {
+	locale, has := locales[strings.ToLower(tag)]
+	if !has {
+		return fmt.Errorf("unknown locale, expected one of %s: '%s'", strings.Join(Locales(), ", "), tag)
+	}
+
+	// The keymap may only replace some of the locale's aliases
+	previous := current
+	current = locale
+	if err := checkConflicts(); err != nil {
+		current = previous
+		return err
+	}
+	return nil
+}
+
+/**
+ * Gets the locale chosen by the environment. BANK_LANG is used as is, while
+ * LC_ALL and LANG are only used if their language is supported.
+ *
+ * @return Tag of the locale, DEFAULT_LOCALE if the environment doesn't choose one
+ */
+func FromEnv() string {
+	if tag := os.Getenv(LOCALE_ENV); tag != "" {
+		return tag
+	}
+
+	for _, env := range []string{"LC_ALL", "LANG"} {
+		// Such as 'es_MX.UTF-8'
+		tag, _, _ := strings.Cut(os.Getenv(env), ".")
+		tag, _, _ = strings.Cut(tag, "_")
+		if _, has := locales[strings.ToLower(tag)]; has {
+			return strings.ToLower(tag)
+		}
+	}
+	return DEFAULT_LOCALE
+}
+
+/**
+ * Gets a message in the current locale
+ *
+ * @param key Message to get
+ * @param args Values of the message's format verbs
+ *
+ * @return The message, in English if the locale doesn't have it
+ */
+func T(key Key, args ...any) string {
+	msg, has := current.Messages[key]
+	if !has {
+		msg = locales[DEFAULT_LOCALE].Messages[key]
+	}
+
+	if len(args) > 0 {
+		return fmt.Sprintf(msg, args...)
+	}
+	return msg
+}
+
+/**
+ * Gets the aliases of a command from the keymap, otherwise the current locale
+ *
+ * @param command Command to get the aliases of
+ *
+ * @return Lower case aliases of the command
+ */
+func Aliases(command Command) []string {
+	if aliases, has := keymap[command]; has {
+		return aliases
+	} else if aliases, has := current.Commands[command]; has {
+		return aliases
+	}
+	return locales[DEFAULT_LOCALE].Commands[command]
+}
+
+/**
+ * Describes what can be typed for a command in a prompt
+ *
+ * @param command Command to describe
+ *
+ * @return The first two aliases quoted, such as 'd' or 'done'
+ */
+func Choices(command Command) string {
+	quoted := make([]string, 0, 2)
+	for _, alias := range Aliases(command) {
+		if alias != "" && len(quoted) < 2 {
+			quoted = append(quoted, "'"+alias+"'")
+		}
+	}
+	if len(quoted) < 2 {
+		return strings.Join(quoted, "")
+	}
+	return T(WORD_OR, quoted[0], quoted[1])
+}
+
+/**
+ * Dictates if the input is one of a command's aliases
+ *
+ * @param command Command to check
+ * @param input Input of the player
+ *
+ * @return True if the input is an alias of the command, otherwise false
+ */
+func Is(command Command, input string) bool {
+	return slices.Contains(Aliases(command), strings.ToLower(input))
+}
+
+/**
+ * Finds which command the input is an alias of
+ *
+ * @param input Input of the player
+ * @param commands Commands the input could be
+ *
+ * @return The command, and false if the input isn't an alias of any of them
+ */
+func Lookup(input string, commands ...Command) (Command, bool) {
+	for _, command := range commands {
+		if Is(command, input) {
+			return command, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,123 @@
+package i18n
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+)
+
+var formatVerb = regexp.MustCompile(`%[-+# 0-9.]*[a-zA-Z%]`)
+
+func TestEveryMessageInEveryLocale(t *testing.T) {
+	for tag, locale := range locales {
+		for key, msg := range english.Messages {
+			translated, has := locale.Messages[key]
+			if !has || translated == "" {
+				t.Errorf("%s: missing message %q", tag, key)
+				continue
+			}
+
+			// Translations must take the same values in the same order
+			want := formatVerb.FindAllString(msg, -1)
+			if got := formatVerb.FindAllString(translated, -1); !slices.Equal(got, want) {
+				t.Errorf("%s: message %q has format verbs %v, expected %v", tag, key, got, want)
+			}
+		}
+
+		for key := range locale.Messages {
+			if _, has := english.Messages[key]; !has {
+				t.Errorf("%s: message %q isn't in English", tag, key)
+			}
+		}
+	}
+}
+
+func TestEveryCommandInEveryLocale(t *testing.T) {
+	for _, command := range MENU_COMMANDS {
+		for _, key := range []Key{MenuAction(command), MenuDescription(command)} {
+			if _, has := english.Messages[key]; !has {
+				t.Errorf("missing menu message %q", key)
+			}
+		}
+	}
+
+	for tag, locale := range locales {
+		for command := range english.Commands {
+			aliases := locale.Commands[command]
+			if len(aliases) == 0 {
+				t.Errorf("%s: command %q has no aliases", tag, command)
+			}
+			for _, alias := range aliases {
+				if alias != strings.ToLower(alias) {
+					t.Errorf("%s: alias %q of %q isn't lower case", tag, alias, command)
+				}
+			}
+		}
+
+		// The main prompt can't tell commands apart if they share an alias
+		seen := make(map[string]Command)
+		for _, command := range MENU_COMMANDS {
+			for _, alias := range locale.Commands[command] {
+				if other, has := seen[alias]; has {
+					t.Errorf("%s: alias %q is used by %q and %q", tag, alias, other, command)
+				}
+				seen[alias] = command
+			}
+		}
+	}
+}
+
+func TestUse(t *testing.T) {
+	defer Use(DEFAULT_LOCALE)
+
+	if err := Use("xx"); err == nil {
+		t.Error("expected an error for an unknown locale")
+	}
+
+	if err := Use("ES"); err != nil {
+		t.Fatal(err)
+	}
+	if got := T(MSG_PLAYER_WON, "Ana"); got != "¡El jugador 'Ana' ganó!" {
+		t.Errorf("unexpected message: %s", got)
+	}
+	if command, found := Lookup("tirar", MENU_COMMANDS...); !found || command != CMD_ROLL {
+		t.Errorf("expected 'tirar' to roll, got %q", command)
+	}
+	if Is(CMD_ROLL, "roll") {
+		t.Error("expected the English alias to not be used in Spanish")
+	}
+}
+
+func TestFallsBackToEnglish(t *testing.T) {
+	locales["xx"] = &Locale{Name: "Test", Messages: map[Key]string{}, Commands: map[Command][]string{}}
+	defer delete(locales, "xx")
+	defer Use(DEFAULT_LOCALE)
+
+	Use("xx")
+	if got := T(MSG_NOBODY_PLAYED); got != "Nobody played!" {
+		t.Errorf("expected the English message, got %q", got)
+	}
+	if !Is(CMD_DONE, "Done") {
+		t.Error("expected the English aliases")
+	}
+}
+
+func TestFromEnv(t *testing.T) {
+	for _, tc := range []struct {
+		bankLang, lcAll, lang, want string
+	}{
+		{"", "", "", DEFAULT_LOCALE},
+		{"", "", "es_MX.UTF-8", "es"},
+		{"", "C", "es_ES.UTF-8", "es"},
+		{"", "", "fr_FR.UTF-8", DEFAULT_LOCALE},
+		{"fr", "", "es_ES.UTF-8", "fr"},
+	} {
+		t.Setenv(LOCALE_ENV, tc.bankLang)
+		t.Setenv("LC_ALL", tc.lcAll)
+		t.Setenv("LANG", tc.lang)
+		if got := FromEnv(); got != tc.want {
+			t.Errorf("%+v: got %q", tc, got)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package i18n
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+/**
+ * Aliases of commands chosen by the players, replacing the aliases of the
+ * locale for every command it has. Such as {"roll": ["", "r", "go"]}.
+ * Pressing enter rolls the dice, so only roll has the "" alias and it must keep it.
+ */
+type Keymap map[Command][]string
+
+// Commands that are typed at the same prompt, so can't share an alias
+var promptGroups = [][]Command{
+	MENU_COMMANDS,
+	{CMD_YES, CMD_NO},
+}
+
+var keymap Keymap
+
+/**
+ * Reads a keymap from a JSON file
+ *
+ * @param path File of the keymap
+ *
+ * @return The keymap with its aliases trimmed and lower cased, or an error if the
+ *         file couldn't be read or has a command that doesn't exist or has no aliases
+ */
+func LoadKeymap(path string) (Keymap, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var km Keymap
+	if err := json.Unmarshal(data, &km); err != nil {
+		return nil, fmt.Errorf("invalid keymap '%s': %w", path, err)
+	}
+
+	for command, aliases := range km {
+		if _, has := english.Commands[command]; !has {
+			return nil, fmt.Errorf("unknown command, expected one of %s: '%s'", strings.Join(commandNames(), ", "), command)
+		} else if len(aliases) == 0 {
+			return nil, fmt.Errorf("command has no aliases: '%s'", command)
+		}
+
+		for idx, alias := range aliases {
+			aliases[idx] = strings.ToLower(strings.TrimSpace(alias))
+		}
+	}
+	return km, nil
+}
+
+/**
+ * Uses the keymap's aliases instead of the locale's
+ *
+ * @param km Keymap to use, nil to only use the locale's aliases
+ *
+ * @return An error if a prompt would have commands sharing an alias, in which case
+ *         the keymap isn't used
+ */
+func UseKeymap(km Keymap) error {
+	previous := keymap
+	keymap = km
+	if err := checkConflicts(); err != nil {
+		keymap = previous
+		return err
+	}
+	return nil
+}
+
+/**
+ * Checks the commands of each prompt have their own aliases
+ *
+ * @return An error naming the alias and the commands sharing it, or the command
+ *         missing or misusing the "" alias of pressing enter
+ */
+func checkConflicts() error {
+	for _, group := range promptGroups {
+		seen := make(map[string]Command)
+		for _, command := range group {
+			for _, alias := range Aliases(command) {
+				if other, has := seen[alias]; has && other != command {
+					return fmt.Errorf("commands '%s' and '%s' share the alias: '%s'", other, command, alias)
+				}
+				seen[alias] = command
+			}
+		}
+	}
+
+	// Pressing enter rolls the dice, and is all a player enters once the input is done
+	if !slices.Contains(Aliases(CMD_ROLL), "") {
+		return fmt.Errorf("aliases of '%s' must have \"\" for pressing enter", CMD_ROLL)
+	}
+	for _, command := range commandNames() {
+		if Command(command) != CMD_ROLL && slices.Contains(Aliases(Command(command)), "") {
+			return fmt.Errorf("only '%s' can have the \"\" alias: '%s'", CMD_ROLL, command)
+		}
+	}
+
+	// Players bank by their number, so it can't be taken for done
+	for _, alias := range Aliases(CMD_DONE) {
+		if _, err := strconv.Atoi(alias); err == nil {
+			return fmt.Errorf("aliases of '%s' can't be numbers: '%s'", CMD_DONE, alias)
+		}
+	}
+	return nil
+}
+
+/**
+ * Gets the names of every command
+ *
+ * @return Names of the commands, sorted
+ */
+func commandNames() []string {
+	names := make([]string, 0, len(english.Commands))
+	for command := range english.Commands {
+		names = append(names, string(command))
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,125 @@
+package i18n
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+/**
+ * Writes a keymap file and loads it
+ */
+func loadKeymap(t *testing.T, data string) (Keymap, error) {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "keymap.json")
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return LoadKeymap(path)
+}
+
+func TestKeymap(t *testing.T) {
+	defer UseKeymap(nil)
+
+	km, err := loadKeymap(t, `{"roll": ["", "R", " go "], "done": ["ok"]}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := UseKeymap(km); err != nil {
+		t.Fatal(err)
+	}
+
+	if !slices.Equal(Aliases(CMD_ROLL), []string{"", "r", "go"}) {
+		t.Errorf("expected the keymap's aliases, got %v", Aliases(CMD_ROLL))
+	}
+	if command, _ := Lookup("go", MENU_COMMANDS...); command != CMD_ROLL {
+		t.Errorf("expected 'go' to roll, got %q", command)
+	}
+	if Is(CMD_ROLL, "roll") {
+		t.Error("expected the keymap to replace the locale's aliases")
+	}
+	if !Is(CMD_BANK, "b") {
+		t.Error("expected the locale's aliases for commands the keymap doesn't have")
+	}
+	if got := T(PROMPT_BANK, Choices(CMD_DONE)); got != "Enter 'ok' to submit" {
+		t.Errorf("expected the prompt to show the keymap: %s", got)
+	}
+}
+
+func TestKeymapErrors(t *testing.T) {
+	defer UseKeymap(nil)
+
+	for _, data := range []string{`{`, `{"dance": ["d"]}`, `{"roll": []}`} {
+		if _, err := loadKeymap(t, data); err == nil {
+			t.Errorf("expected an error loading %s", data)
+		}
+	}
+
+	for _, data := range []string{`{"undo": ["b"]}`, `{"yes": ["n"]}`, `{"done": ["1"]}`, `{"roll": ["r", "go"]}`, `{"bank": ["", "b"]}`, `{"done": ["", "d"]}`} {
+		km, err := loadKeymap(t, data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := UseKeymap(km); err == nil {
+			t.Errorf("expected a conflict using %s", data)
+		}
+	}
+	if !Is(CMD_UNDO, "u") {
+		t.Error("expected a conflicting keymap to not be used")
+	}
+
+	// Spanish banks with 'g', which the keymap uses to roll
+	km, _ := loadKeymap(t, `{"roll": ["", "g"]}`)
+	if err := UseKeymap(km); err != nil {
+		t.Fatal(err)
+	}
+	if err := Use("es"); err == nil || !strings.Contains(err.Error(), "'g'") {
+		t.Errorf("expected a conflict in Spanish, got %v", err)
+	}
+	if current != &english {
+		t.Error("expected to stay in English")
+	}
+}
+
+func TestKeymapEnterAlias(t *testing.T) {
+	defer UseKeymap(nil)
+
+	km, _ := loadKeymap(t, `{"roll": ["r", "go"]}`)
+	if err := UseKeymap(km); err == nil || !strings.Contains(err.Error(), `must have ""`) {
+		t.Errorf("expected roll to need the \"\" alias, got %v", err)
+	}
+
+	km, _ = loadKeymap(t, `{"roll": ["r"], "scoreboard": ["", "s"]}`)
+	if err := UseKeymap(km); err == nil {
+		t.Error("expected moving the \"\" alias off roll to be rejected")
+	}
+
+	km, _ = loadKeymap(t, `{"leave": ["", "l"]}`)
+	if err := UseKeymap(km); err == nil || !strings.Contains(err.Error(), "'leave'") {
+		t.Errorf("expected only roll to have the \"\" alias, got %v", err)
+	}
+	if !Is(CMD_ROLL, "") || Is(CMD_LEAVE, "") {
+		t.Error("expected a rejected keymap to not be used")
+	}
+}
+
+func TestExampleKeymap(t *testing.T) {
+	defer UseKeymap(nil)
+
+	km, err := LoadKeymap(filepath.Join("..", "keymap.example.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := UseKeymap(km); err != nil {
+		t.Errorf("expected the example keymap to be usable: %v", err)
+	}
+	for _, locale := range Locales() {
+		if err := Use(locale); err != nil {
+			t.Errorf("expected the example keymap to work in %s: %v", locale, err)
+		}
+	}
+	Use("en")
+}
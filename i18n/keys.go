@@ -0,0 +1,181 @@
+package i18n
+
+// Commands typed at the prompts
+const (
+	CMD_HELP       Command = "help"
+	CMD_POINTS     Command = "points"
+	CMD_BANK       Command = "bank"
+	CMD_SCOREBOARD Command = "scoreboard"
+	CMD_UNDO       Command = "undo"
+	CMD_JOIN       Command = "join"
+	CMD_LEAVE      Command = "leave"
+	CMD_ROLL       Command = "roll"
+
+	CMD_DONE     Command = "done"     // Stops adding or banking players
+	CMD_YES      Command = "yes"      // Answers a yes or no question
+	CMD_NO       Command = "no"       // Answers a yes or no question
+	CMD_STAND_IN Command = "stand-in" // An AI Agent takes over for a player leaving
+)
+
+// Commands of the main prompt, in the order the menu lists them
+var MENU_COMMANDS = []Command{CMD_POINTS, CMD_BANK, CMD_SCOREBOARD, CMD_UNDO, CMD_JOIN, CMD_LEAVE, CMD_ROLL, CMD_HELP}
+
+// Prompts
+const (
+	PROMPT_MAIN           Key = "prompt.main"
+	PROMPT_BANK           Key = "prompt.bank"
+	PROMPT_PLAYER_NAME    Key = "prompt.player_name"
+	PROMPT_HOST_NAME      Key = "prompt.host_name"
+	PROMPT_STARTING_PTS   Key = "prompt.starting_points"
+	PROMPT_STAND_IN       Key = "prompt.stand_in"
+	PROMPT_ADD_AI_AGENTS  Key = "prompt.add_ai_agents"
+	PROMPT_STOP_ADDING    Key = "prompt.stop_adding"
+	PROMPT_INVALID_INPUT  Key = "prompt.invalid_input"
+	PROMPT_INVALID_PLAYER Key = "prompt.invalid_player"
+	PROMPT_INVALID_POINTS Key = "prompt.invalid_points"
+)
+
+// Menu of the main prompt
+const (
+	MENU_ACTION       Key = "menu.action"
+	MENU_COMMANDS_HDR Key = "menu.commands"
+	MENU_DESCRIPTION  Key = "menu.description"
+	MENU_ENTER        Key = "menu.enter" // Shown for the alias of pressing enter
+	WORD_OR           Key = "word.or"    // Joins two choices
+)
+
+// Messages while playing
+const (
+	MSG_STARTING_GAME     Key = "msg.starting_game"
+	MSG_ROUND_OF          Key = "msg.round_of"
+	MSG_ROUND_TARGET      Key = "msg.round_target"
+	MSG_ROUND_ELIMINATION Key = "msg.round_elimination"
+	MSG_ROUND_STARTING    Key = "msg.round_starting"
+	MSG_ROUND_DONE        Key = "msg.round_done"
+	MSG_CURRENT_POINTS    Key = "msg.current_points"
+	MSG_ROLL_NUMBER       Key = "msg.roll_number"
+	MSG_AI_BANKED         Key = "msg.ai_banked"
+	MSG_ALL_HUMANS_BANKED Key = "msg.all_humans_banked"
+	MSG_KNOCKED_OUT       Key = "msg.knocked_out"
+	MSG_CANT_UNDO         Key = "msg.cant_undo"
+	MSG_UNDONE            Key = "msg.undone"
+	MSG_CANT_JOIN         Key = "msg.cant_join"
+	MSG_JOINS_NEXT_ROUND  Key = "msg.joins_next_round"
+	MSG_CANT_LEAVE        Key = "msg.cant_leave"
+	MSG_LEFT_GAME         Key = "msg.left_game"
+	MSG_NOBODY_PLAYED     Key = "msg.nobody_played"
+	MSG_PLAYER_WON        Key = "msg.player_won"
+	MSG_PLAYERS_TIED      Key = "msg.players_tied"
+	MSG_TEAM_WON          Key = "msg.team_won"
+	MSG_TEAMS_TIED        Key = "msg.teams_tied"
+	MSG_MATCH_WON         Key = "msg.match_won"
+	MSG_PLAYER_NOT_ADDED  Key = "msg.player_not_added"
+	MSG_ADDING_AI_AGENTS  Key = "msg.adding_ai_agents"
+	MSG_REPORT_WRITTEN    Key = "msg.report_written"
+	MSG_HOSTING_GAMES     Key = "msg.hosting_games"
+	MSG_HOSTING_ARENA     Key = "msg.hosting_arena"
+)
+
+// Table titles
+const (
+	TITLE_GAME_MODE     Key = "title.game_mode"
+	TITLE_SCOREBOARD    Key = "title.scoreboard"
+	TITLE_GAME_REPORT   Key = "title.game_report"
+	TITLE_PLAYER_REPORT Key = "title.player_report"
+	TITLE_REPORT        Key = "title.report" // Heading of the Markdown report
+	TITLE_MATCH         Key = "title.match"
+	TITLE_FAULTS        Key = "title.faults"
+	TITLE_UNDONE_BANKS  Key = "title.undone_banks"
+	TITLE_LEADERBOARD   Key = "title.leaderboard"
+)
+
+// Table headers
+const (
+	HDR_PLAYER        Key = "hdr.player"
+	HDR_PLAYERS       Key = "hdr.players"
+	HDR_AI_AGENT      Key = "hdr.ai_agent"
+	HDR_AGENT         Key = "hdr.agent"
+	HDR_BANKED        Key = "hdr.banked"
+	HDR_POINTS        Key = "hdr.points"
+	HDR_TOTAL_POINTS  Key = "hdr.total_points"
+	HDR_FAULTS        Key = "hdr.faults"
+	HDR_FAULT         Key = "hdr.fault"
+	HDR_DETAIL        Key = "hdr.detail"
+	HDR_KNOCKED_OUT   Key = "hdr.knocked_out"
+	HDR_HANDICAP      Key = "hdr.handicap"
+	HDR_SEAT          Key = "hdr.seat"
+	HDR_TEAM          Key = "hdr.team"
+	HDR_TEAM_POINTS   Key = "hdr.team_points"
+	HDR_ROUND         Key = "hdr.round"
+	HDR_ROLL          Key = "hdr.roll"
+	HDR_POT           Key = "hdr.pot"
+	HDR_RULE          Key = "hdr.rule"
+	HDR_STAT          Key = "hdr.stat"
+	HDR_VALUE         Key = "hdr.value"
+	HDR_BANKS         Key = "hdr.banks"
+	HDR_AVG_BANK      Key = "hdr.avg_bank"
+	HDR_LEFT_ON_TABLE Key = "hdr.left_on_table"
+	HDR_ROLL_EARLIER  Key = "hdr.roll_earlier"
+	HDR_ROLL_LATER    Key = "hdr.roll_later"
+	HDR_HOST          Key = "hdr.host"
+	HDR_BANKS_UNDONE  Key = "hdr.banks_undone"
+	HDR_WINS          Key = "hdr.wins"
+	HDR_GAMES         Key = "hdr.games"
+	HDR_RANK          Key = "hdr.rank"
+)
+
+// Table cells
+const (
+	CELL_DISQUALIFIED   Key = "cell.disqualified" // Faults of a disqualified agent
+	CELL_KNOCKED_OUT_IN Key = "cell.knocked_out_in"
+	CELL_FORFEITED      Key = "cell.forfeited"
+	CELL_STAND_IN       Key = "cell.stand_in"
+	CELL_JOINED         Key = "cell.joined"
+	CELL_AI             Key = "cell.ai" // Marks an AI Agent on a team
+	CELL_FIRST_TO       Key = "cell.first_to"
+	CELL_ELIMINATION    Key = "cell.elimination"
+	CELL_EVERY_ROUNDS   Key = "cell.every_rounds"
+	CELL_MOST_ROUNDS    Key = "cell.most_rounds"
+	CELL_START          Key = "cell.start" // Starting points on the scoreboard
+	CELL_TOTAL          Key = "cell.total"
+	CELL_BUSTED_POT     Key = "cell.busted_pot" // Pot of a round that ended with a 7
+	CELL_ROUNDS         Key = "cell.rounds"
+	CELL_LONGEST_ROUND  Key = "cell.longest_round"
+	CELL_ROUND_ROLLS    Key = "cell.round_rolls"
+	CELL_HIGHEST_POT    Key = "cell.highest_pot"
+	CELL_POT_IN_ROUND   Key = "cell.pot_in_round"
+	CELL_SEVENS         Key = "cell.sevens"
+	CELL_DOUBLES        Key = "cell.doubles"
+	CELL_UNKNOWN        Key = "cell.unknown" // Banks whose next roll never happened
+	CELL_FAULT_DQ       Key = "cell.fault_dq"
+	CELL_FAULT_TIMEOUT  Key = "cell.fault_timeout" // Kinds of faults an AI Agent makes
+	CELL_FAULT_PANIC    Key = "cell.fault_panic"
+	CELL_FAULT_INVALID  Key = "cell.fault_invalid"
+	CELL_HANDICAP_PTS   Key = "cell.handicap_points"
+	CELL_SAFE_ROLL      Key = "cell.safe_roll"
+	CELL_SAFE_ROLLS     Key = "cell.safe_rolls"
+	CELL_NO_ROUNDS      Key = "cell.no_rounds" // Chart before any round was played
+	CELL_CHART_AXIS     Key = "cell.chart_axis"
+)
+
+/**
+ * Gets the key of a menu command's action name
+ *
+ * @param command Command of the main prompt
+ *
+ * @return Key of the action's name
+ */
+func MenuAction(command Command) Key {
+	return Key("menu." + string(command) + ".action")
+}
+
+/**
+ * Gets the key of a menu command's description
+ *
+ * @param command Command of the main prompt
+ *
+ * @return Key of the action's description
+ */
+func MenuDescription(command Command) Key {
+	return Key("menu." + string(command) + ".description")
+}
@@ -1,44 +1,518 @@
 package main
 
 import (
+	"bufio"
+	"flag"
 	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"strings"
 
+	"github.com/Sparhawk96/bank-ais/agents"
+	"github.com/Sparhawk96/bank-ais/arena"
 	"github.com/Sparhawk96/bank-ais/game"
+	"github.com/Sparhawk96/bank-ais/i18n"
+	"github.com/Sparhawk96/bank-ais/metrics"
+	"github.com/Sparhawk96/bank-ais/server"
+	"github.com/Sparhawk96/bank-ais/table"
+	"github.com/Sparhawk96/bank-ais/web"
 )
 
 func main() {
-	bankGame := game.NewGame()
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+/**
+ * Runs the game with its command line arguments
+ *
+ * @param args Command line arguments, without the program name
+ * @param in Reader of the players' input
+ * @param out Writer the game is printed to
+ * @param errOut Writer errors are printed to
+ *
+ * @return Exit code, 0 on success
+ */
+func run(args []string, in io.Reader, out io.Writer, errOut io.Writer) int {
+	flags := flag.NewFlagSet("bank", flag.ContinueOnError)
+	flags.SetOutput(errOut)
+
+	reportPath := flags.String("report", "", "Writes the post game report to a .md or .json file")
+	serveAddr := flags.String("serve", "", "Hosts games over HTTP on the address, such as ':8080', instead of the terminal")
+	arenaAddr := flags.String("arena", "", "Hosts games between remote AI Agents over TCP on the address, such as ':9090'")
+	arenaPlayers := flags.Int("arena-players", arena.DEFAULT_PLAYERS_PER_GAME, "Agents matched into each arena game")
+	arenaDeadline := flags.Duration("arena-deadline", arena.DEFAULT_DEADLINE, "Time an arena agent has to decide each roll")
+	leaderboardPath := flags.String("leaderboard", "leaderboard.json", "File the arena leaderboard is kept in")
+	agentTimeout := flags.Duration("agent-timeout", game.DEFAULT_AGENT_TIMEOUT, "Time an AI Agent has to decide each roll, 0 to wait forever")
+	maxFaults := flags.Int("max-faults", 0, "Faults that disqualify an AI Agent, 0 to never disqualify")
+	teams := flags.String("teams", "", "Plays in teams such as 'Red=Ann,Bob;Blue=Cat,Dan', every player must be on a team")
+	teamsBankTogether := flags.Bool("bank-together", false, "One member banking banks their whole team")
+	rounds := flags.Int("rounds", 0, "Rounds played, or the most rounds with -target or -eliminate-every")
+	target := flags.Uint("target", 0, "Ends the game once someone reaches this many points")
+	eliminateEvery := flags.Int("eliminate-every", 0, "Knocks out the lowest scorer every this many rounds")
+	bestOf := flags.Int("best-of", 1, "Plays a match of up to this many games")
+	host := flags.String("host", "", "Player who can undo the last bank before the next roll")
+	locale := flags.String("lang", "", "Language of the game, one of "+strings.Join(i18n.Locales(), ", ")+", otherwise chosen by $"+i18n.LOCALE_ENV+" or $LANG")
+	keymapPath := flags.String("keymap", "", "JSON file of command aliases such as '{\"roll\": [\"\", \"r\", \"go\"]}'")
+	handicapList := flags.String("handicaps", "", "Handicaps players such as 'Ann=start:100,multiplier:1.5,safe:2;Bob=start:50'")
+	scriptPath := flags.String("script", "", "File of commands typed in order, one per line, lines starting with '#' are skipped")
+	logPath := flags.String("log", "", "Traces rolls, points, AI Agent decisions and ranking changes to the file")
+	logLevel := flags.String("log-level", "debug", "Lowest level traced, one of debug, info, warn, error")
+	logFormat := flags.String("log-format", "json", "Format of the trace, json or text")
+	metricsAddr := flags.String("metrics", "", "Serves metrics in the Prometheus text format on the address's /metrics, such as 'localhost:9100'")
+	metricsEvery := flags.Duration("metrics-every", 0, "Prints a summary of the metrics to stderr this often, 0 to never")
+	seed := flags.Int64("seed", 0, "Seed of the dice, each game of a match uses the next seed, otherwise random")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	seeded := false
+	flags.Visit(func(f *flag.Flag) { seeded = seeded || f.Name == "seed" })
+
+	game.SetOutput(out)
+	table.ColorsEnabled = table.ColorsFor(out)
+	if *scriptPath != "" {
+		script, err := readScript(*scriptPath)
+		if err != nil {
+			fmt.Fprintln(errOut, "Invalid script:", err)
+			return 1
+		}
+		game.SetInput(script, true)
+	} else {
+		game.SetInput(in, false)
+	}
+
+	if *locale == "" {
+		*locale = i18n.FromEnv()
+	}
+	if err := i18n.Use(*locale); err != nil {
+		fmt.Fprintln(errOut, "Invalid language:", err)
+		return 1
+	}
+	if *keymapPath != "" {
+		if err := useKeymap(*keymapPath); err != nil {
+			fmt.Fprintln(errOut, "Invalid keymap:", err)
+			return 1
+		}
+	}
+
+	var logger *slog.Logger
+	if *logPath != "" {
+		var logFile io.Closer
+		var err error
+		if logger, logFile, err = openLog(*logPath, *logLevel, *logFormat); err != nil {
+			fmt.Fprintln(errOut, "Invalid log:", err)
+			return 1
+		}
+		defer logFile.Close()
+	}
+
+	var collector *metrics.Collector
+	if *metricsAddr != "" || *metricsEvery > 0 {
+		collector = metrics.New()
+	}
+	if *metricsAddr != "" {
+		addr, metricsServer, err := serveMetrics(*metricsAddr, collector)
+		if err != nil {
+			fmt.Fprintln(errOut, "Failed to serve the metrics:", err)
+			return 1
+		}
+		defer metricsServer.Close()
+		fmt.Fprintf(errOut, "Serving metrics on 'http://%s/metrics'\n", addr)
+	}
+	if *metricsEvery > 0 {
+		stop := collector.Report(errOut, *metricsEvery)
+		defer stop()
+	}
+
+	if *arenaAddr != "" {
+		var observer game.Observer
+		if collector != nil {
+			observer = collector
+		}
+
+		if err := hostArena(out, *arenaAddr, arena.Config{
+			PlayersPerGame:  *arenaPlayers,
+			Deadline:        *arenaDeadline,
+			LeaderboardPath: *leaderboardPath,
+			MaxFaults:       *maxFaults,
+			Logger:          logger,
+			Observer:        observer,
+		}); err != nil {
+			fmt.Fprintln(errOut, "Failed to host the arena:", err)
+			return 1
+		}
+		return 0
+	}
+
+	if *serveAddr != "" {
+		host := server.New()
+		defer host.Close()
+		host.SetLogger(logger)
+		if collector != nil {
+			host.AddObserver(collector)
+		}
+		host.Handle("/", web.Handler())
 
-	fmt.Println("Enter 'd' or 'done' to stop adding players.")
+		fmt.Fprintf(out, "%s\n\r", i18n.T(i18n.MSG_HOSTING_GAMES, *serveAddr))
+		if err := http.ListenAndServe(*serveAddr, host); err != nil {
+			fmt.Fprintln(errOut, "Failed to host games:", err)
+			return 1
+		}
+		return 0
+	}
+
+	mode := game.Mode{Rounds: *rounds, TargetScore: *target, EliminateEvery: *eliminateEvery}
+	newGame := func(played int) (*game.Game, error) {
+		bankGame := game.NewGame()
+		bankGame.SetAgentTimeout(*agentTimeout)
+		bankGame.SetMaxFaults(*maxFaults)
+		bankGame.SetLogger(logger)
+		if collector != nil {
+			bankGame.AddObserver(collector)
+		}
+		bankGame.SetStandIn(func(name string) game.Player {
+			return agents.NewThreshold(name, server.DEFAULT_AI_BANK_AT)
+		})
+		if seeded {
+			if err := bankGame.SetSeed(*seed + int64(played)); err != nil {
+				return nil, err
+			}
+		}
+		return bankGame, bankGame.SetMode(mode)
+	}
+
+	handicaps, err := parseHandicaps(*handicapList)
+	if err != nil {
+		fmt.Fprintln(errOut, "Invalid handicaps:", err)
+		return 1
+	}
+
+	match, err := game.NewMatch(*bestOf)
+	if err != nil {
+		fmt.Fprintln(errOut, "Invalid match:", err)
+		return 1
+	}
 
-	for keepPrompting := true; keepPrompting; {
-		input := game.GetInput("Enter Player Name "+game.PROMPT, false)
-		if input == "d" || input == "D" || input == "done" || input == "Done" || input == "DONE" {
+	bankGame, err := newGame(0)
+	if err != nil {
+		fmt.Fprintln(errOut, "Invalid game mode:", err)
+		return 1
+	}
+
+	fmt.Fprintln(out, i18n.T(i18n.PROMPT_STOP_ADDING, i18n.Choices(i18n.CMD_DONE)))
+
+	for keepPrompting := true; keepPrompting && !game.InputDone(); {
+		input := game.GetInput(i18n.T(i18n.PROMPT_PLAYER_NAME)+" "+game.PROMPT, false)
+		if i18n.Is(i18n.CMD_DONE, input) {
 			keepPrompting = false
 		} else if len(input) == 0 {
 			// NO-OP
-		} else if bankGame.AddPlayer(game.NewHumanPlayer(input)) != nil {
-			fmt.Println("Player already exists with that name.")
+		} else if bankGame.AddPlayer(game.NewHumanPlayer(input), handicaps[input]) != nil {
+			fmt.Fprintln(out, i18n.T(i18n.MSG_PLAYER_NOT_ADDED))
 		}
 	}
 
-	for keepPrompting := true; keepPrompting; {
-		input := game.GetInput("Add AI Agents (y/n) "+game.PROMPT, true)
-		switch input {
-		case "no", "n":
+	for keepPrompting := true; keepPrompting && !game.InputDone(); {
+		input := game.GetInput(i18n.T(i18n.PROMPT_ADD_AI_AGENTS, i18n.Aliases(i18n.CMD_YES)[0], i18n.Aliases(i18n.CMD_NO)[0])+" "+game.PROMPT, true)
+		switch command, _ := i18n.Lookup(input, i18n.CMD_NO, i18n.CMD_YES); command {
+		case i18n.CMD_NO:
 			keepPrompting = false
-		case "yes", "y":
+		case i18n.CMD_YES:
 			keepPrompting = false
-			addAiAgents(bankGame)
+			addAiAgents(out, bankGame)
+		}
+	}
+
+	players := bankGame.Players()
+	for name := range handicaps {
+		if !slices.ContainsFunc(players, func(player game.Player) bool { return player.Name() == name }) {
+			fmt.Fprintf(errOut, "Handicapped player isn't playing: '%s'\n", name)
+			return 1
 		}
 	}
 
-	fmt.Println()
-	bankGame.StartGame()
+	for played := 1; ; played++ {
+		if *host != "" {
+			if err := bankGame.SetHost(*host); err != nil {
+				fmt.Fprintln(errOut, "Invalid host:", err)
+				return 1
+			}
+		}
+
+		if *teams != "" {
+			if err := addTeams(bankGame, *teams, *teamsBankTogether); err != nil {
+				fmt.Fprintln(errOut, "Failed to add the teams:", err)
+				return 1
+			}
+		}
+
+		fmt.Fprintln(out)
+		if err := bankGame.StartGame(); err != nil {
+			fmt.Fprintln(errOut, "Failed to start the game:", err)
+			return 1
+		}
+
+		if *reportPath != "" {
+			if err := writeReport(bankGame, *reportPath); err != nil {
+				fmt.Fprintln(errOut, "Failed to write the report:", err)
+				return 1
+			}
+			fmt.Fprintf(out, "%s\n\r", i18n.T(i18n.MSG_REPORT_WRITTEN, *reportPath))
+		}
+
+		if *bestOf <= 1 {
+			return 0
+		}
+
+		match.Record(bankGame)
+		fmt.Fprintln(out, match)
+		if match.Over() {
+			fmt.Fprintf(out, "%s\n\r", i18n.T(i18n.MSG_MATCH_WON, strings.Join(match.Winners(), "', '")))
+			return 0
+		}
+
+		// Same players in a new game
+		bankGame, _ = newGame(played)
+		for _, player := range players {
+			bankGame.AddPlayer(player, handicaps[player.Name()])
+		}
+	}
+}
+
+/**
+ * Writes the post game report to a file
+ *
+ * @param bankGame Game that was played
+ * @param path File to write, JSON if it ends with .json otherwise Markdown
+ *
+ * @return An error if the report couldn't be written
+ */
+func writeReport(bankGame *game.Game, path string) error {
+	report := bankGame.Report()
+	data := []byte(report.Markdown())
+
+	if filepath.Ext(path) == ".json" {
+		var err error
+		if data, err = report.JSON(); err != nil {
+			return err
+		}
+	}
+
+	return os.WriteFile(path, data, 0644)
+}
+
+/**
+ * Adds the teams to the game
+ *
+ * @param bankGame Game to add the teams to
+ * @param teams Teams such as 'Red=Ann,Bob;Blue=Cat,Dan'
+ * @param bankTogether True if one member banking banks their whole team
+ *
+ * @return An error if a team couldn't be added
+ */
+func addTeams(bankGame *game.Game, teams string, bankTogether bool) error {
+	for _, team := range strings.Split(teams, ";") {
+		name, members, found := strings.Cut(team, "=")
+		if !found {
+			return fmt.Errorf("expected 'Name=Player,Player' for team: '%s'", team)
+		}
+
+		names := strings.Split(members, ",")
+		for idx := range names {
+			names[idx] = strings.TrimSpace(names[idx])
+		}
+		if err := bankGame.AddTeam(strings.TrimSpace(name), names...); err != nil {
+			return err
+		}
+	}
+
+	if bankTogether {
+		return bankGame.SetTeamRule(game.TEAM_BANK_TOGETHER)
+	}
+	return nil
+}
+
+/**
+ * Uses the command aliases of a keymap file
+ *
+ * @param path JSON file of the keymap
+ *
+ * @return An error if the keymap couldn't be read or has conflicting aliases
+ */
+func useKeymap(path string) error {
+	keymap, err := i18n.LoadKeymap(path)
+	if err != nil {
+		return err
+	}
+	return i18n.UseKeymap(keymap)
+}
+
+/**
+ * Serves the metrics in the background
+ *
+ * @param addr TCP address to listen on
+ * @param collector Metrics to serve on /metrics
+ *
+ * @return Address the metrics are served on and the server to close once done,
+ *         or an error if it couldn't be listened on
+ */
+func serveMetrics(addr string, collector *metrics.Collector) (net.Addr, io.Closer, error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("GET /metrics", collector.Handler())
+	metricsServer := &http.Server{Handler: mux}
+	go metricsServer.Serve(listener)
+	return listener.Addr(), metricsServer, nil
+}
+
+/**
+ * Opens the file the games are traced to
+ *
+ * @param path File to append the trace to
+ * @param level Lowest level traced, such as 'debug' or 'info'
+ * @param format Format of the trace, 'json' or 'text'
+ *
+ * @return The logger and the file to close once done, or an error if the level or
+ *         format isn't known or the file couldn't be opened
+ */
+func openLog(path string, level string, format string) (*slog.Logger, io.Closer, error) {
+	var opts slog.HandlerOptions
+	var lvl slog.Level
+	if err := lvl.UnmarshalText([]byte(level)); err != nil {
+		return nil, nil, fmt.Errorf("unknown log level: '%s'", level)
+	}
+	opts.Level = lvl
+
+	if format != "json" && format != "text" {
+		return nil, nil, fmt.Errorf("unknown log format, expected json or text: '%s'", format)
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if format == "text" {
+		return slog.New(slog.NewTextHandler(file, &opts)), file, nil
+	}
+	return slog.New(slog.NewJSONHandler(file, &opts)), file, nil
+}
+
+/**
+ * Reads a script of commands, skipping its comments
+ *
+ * @param path File of the script, one command per line, lines starting with '#' are comments
+ *
+ * @return Reader of the commands, or an error if the file couldn't be read
+ */
+func readScript(path string) (io.Reader, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var commands strings.Builder
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if line := scanner.Text(); !strings.HasPrefix(strings.TrimSpace(line), "#") {
+			commands.WriteString(line + "\n")
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return strings.NewReader(commands.String()), nil
+}
+
+/**
+ * Parses the handicaps of players
+ *
+ * @param list Handicaps such as 'Ann=start:100,multiplier:1.5,safe:2;Bob=start:50'
+ *
+ * @return Handicaps by player name, or an error if one couldn't be parsed
+ */
+func parseHandicaps(list string) (map[string]game.Handicap, error) {
+	handicaps := make(map[string]game.Handicap)
+	if list == "" {
+		return handicaps, nil
+	}
+
+	for _, entry := range strings.Split(list, ";") {
+		name, rules, found := strings.Cut(entry, "=")
+		name = strings.TrimSpace(name)
+		if !found || name == "" {
+			return nil, fmt.Errorf("expected 'Name=rule:value,rule:value' for handicap: '%s'", entry)
+		}
+
+		var handicap game.Handicap
+		for _, rule := range strings.Split(rules, ",") {
+			key, value, _ := strings.Cut(strings.TrimSpace(rule), ":")
+			var err error
+			switch key {
+			case "start":
+				_, err = fmt.Sscan(value, &handicap.StartingPoints)
+			case "multiplier":
+				_, err = fmt.Sscan(value, &handicap.Multiplier)
+			case "safe":
+				_, err = fmt.Sscan(value, &handicap.ExtraSafeRolls)
+			default:
+				return nil, fmt.Errorf("unknown handicap rule: '%s'", key)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("invalid value for handicap rule '%s': '%s'", key, value)
+			}
+		}
+		handicaps[name] = handicap
+	}
+	return handicaps, nil
+}
+
+/**
+ * Hosts the arena until interrupted, then prints the leaderboard
+ *
+ * @param out Writer the leaderboard is printed to
+ * @param addr TCP address to listen on
+ * @param config Arena configuration
+ *
+ * @return An error if the arena couldn't be hosted
+ */
+func hostArena(out io.Writer, addr string, config arena.Config) error {
+	host, err := arena.New(config)
+	if err != nil {
+		return err
+	}
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	go func() {
+		<-interrupt
+		host.Close()
+	}()
+
+	fmt.Fprintf(out, "%s\n\r", i18n.T(i18n.MSG_HOSTING_ARENA, listener.Addr()))
+	err = host.Serve(listener)
+	fmt.Fprintln(out, host.LeaderboardString())
+	return err
 }
 
-func addAiAgents(game *game.Game) {
-	fmt.Println("Adding in AI Agents ...")
+func addAiAgents(out io.Writer, game *game.Game) {
+	fmt.Fprintln(out, i18n.T(i18n.MSG_ADDING_AI_AGENTS))
 
 	// TODO
 }
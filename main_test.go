@@ -0,0 +1,181 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Sparhawk96/bank-ais/game"
+	"github.com/Sparhawk96/bank-ais/i18n"
+	"github.com/Sparhawk96/bank-ais/table"
+)
+
+var update = flag.Bool("update", false, "update the golden files in testdata")
+
+/**
+ * Runs the game with a script of commands in testdata
+ *
+ * @param t Test the game is run for
+ * @param name Name of the script in testdata
+ * @param args Command line arguments besides the script
+ *
+ * @return What the game printed
+ */
+func runScript(t *testing.T, name string, args ...string) string {
+	t.Helper()
+
+	out, _ := runScriptErr(t, name, args...)
+	return out
+}
+
+/**
+ * Runs the game with a script of commands in testdata
+ *
+ * @param t Test the game is run for
+ * @param name Name of the script in testdata
+ * @param args Command line arguments besides the script
+ *
+ * @return What the game printed, and what it printed to stderr
+ */
+func runScriptErr(t *testing.T, name string, args ...string) (string, string) {
+	t.Helper()
+
+	t.Setenv(i18n.LOCALE_ENV, i18n.DEFAULT_LOCALE)
+	colors := table.ColorsEnabled
+	table.ColorsEnabled = false
+	defer func() { table.ColorsEnabled = colors }()
+	defer i18n.Use(i18n.DEFAULT_LOCALE)
+	defer game.SetOutput(os.Stdout)
+	defer game.SetInput(os.Stdin, false)
+
+	var out, errOut bytes.Buffer
+	args = append(args, "-script", filepath.Join("testdata", name+".script"))
+	if code := run(args, strings.NewReader(""), &out, &errOut); code != 0 {
+		t.Fatalf("exited with %d: %s", code, errOut.String())
+	}
+	return out.String(), errOut.String()
+}
+
+/**
+ * Compares what was printed to the golden file, or updates it with -update
+ *
+ * @param t Test doing the comparison
+ * @param name Name of the golden file in testdata
+ * @param actual What was printed
+ */
+func checkGolden(t *testing.T, name string, actual string) {
+	t.Helper()
+
+	path := filepath.Join("testdata", name+".golden")
+	if *update {
+		if err := os.WriteFile(path, []byte(actual), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	expected, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actual != string(expected) {
+		t.Errorf("%s doesn't match the golden file, rerun with -update if the change is intended\n got:\n%s\nexpected:\n%s",
+			path, actual, expected)
+	}
+}
+
+func TestGoldenScripts(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		args []string
+	}{
+		{"bank_points", []string{"-seed", "7", "-rounds", "2"}},
+		{"help_invalid", []string{"-seed", "42", "-rounds", "2"}},
+		{"spanish", []string{"-seed", "3", "-rounds", "1", "-lang", "es"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			checkGolden(t, tc.name, runScript(t, tc.name, tc.args...))
+		})
+	}
+}
+
+func TestScriptIsReplayable(t *testing.T) {
+	args := []string{"-seed", "7", "-rounds", "2"}
+	if first, second := runScript(t, "bank_points", args...), runScript(t, "bank_points", args...); first != second {
+		t.Error("expected the same script and seed to print the same game")
+	}
+}
+
+func TestScriptEndsEarly(t *testing.T) {
+	// Once the script runs out the dice keep rolling until the game is over
+	out := runScript(t, "spanish", "-seed", "3", "-rounds", "3", "-lang", "es")
+	if !strings.Contains(out, "¡Ronda 3 terminada!") {
+		t.Errorf("expected every round to be played:\n%s", out)
+	}
+}
+
+func TestMissingScript(t *testing.T) {
+	var out, errOut bytes.Buffer
+	if code := run([]string{"-script", filepath.Join("testdata", "missing.script")}, strings.NewReader(""), &out, &errOut); code != 1 {
+		t.Errorf("expected exit code 1, got %d", code)
+	}
+	if !strings.Contains(errOut.String(), "Invalid script") {
+		t.Errorf("unexpected error: %s", errOut.String())
+	}
+}
+
+func TestScriptWithLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bank.log")
+	out := runScript(t, "bank_points", "-seed", "7", "-rounds", "2", "-log", path, "-log-format", "text")
+
+	// The trace is only written to the file
+	if strings.Contains(out, "msg=") {
+		t.Error("expected the trace to not be printed")
+	}
+
+	trace, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, msg := range []string{"msg=\"game started\"", "msg=roll", "msg=bank", "msg=\"ranking changed\""} {
+		if !strings.Contains(string(trace), msg) {
+			t.Errorf("expected %s in the trace:\n%s", msg, trace)
+		}
+	}
+}
+
+func TestInvalidLog(t *testing.T) {
+	for _, args := range [][]string{
+		{"-log-level", "loud"},
+		{"-log-format", "xml"},
+	} {
+		var out, errOut bytes.Buffer
+		args = append(args, "-log", filepath.Join(t.TempDir(), "bank.log"))
+		if code := run(args, strings.NewReader(""), &out, &errOut); code != 1 {
+			t.Errorf("%v: expected exit code 1, got %d", args, code)
+		}
+	}
+}
+
+func TestScriptWithMetrics(t *testing.T) {
+	_, errOut := runScriptErr(t, "bank_points", "-seed", "7", "-rounds", "2", "-metrics-every", "1h", "-metrics", "localhost:0")
+
+	if !strings.Contains(errOut, "Serving metrics on 'http://127.0.0.1:") {
+		t.Errorf("expected where the metrics are served: %s", errOut)
+	}
+	// The last summary is printed once the game is over
+	if !strings.Contains(errOut, "metrics: games=1/1 ") {
+		t.Errorf("expected a summary of the game: %s", errOut)
+	}
+
+	// The metrics stop being served once the game is over
+	_, served, _ := strings.Cut(errOut, "'http://")
+	addr, _, _ := strings.Cut(served, "/metrics'")
+	if conn, err := net.Dial("tcp", addr); err == nil {
+		conn.Close()
+		t.Errorf("expected the metrics server on '%s' to be closed", addr)
+	}
+}
@@ -0,0 +1,300 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Sparhawk96/bank-ais/game"
+)
+
+// Upper bounds of the banked points buckets
+var BANK_POINTS_BUCKETS = []float64{10, 25, 50, 100, 200, 400, 800, 1600}
+
+// Upper bounds of the AI Agent decision latency buckets, in seconds
+var LATENCY_BUCKETS = []float64{0.0001, 0.0005, 0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1, 2, 5}
+
+/**
+ * Game observer that counts what happens in every game it observes, such as
+ * rolls, busts and banks. Safe to observe many games at the same time.
+ */
+type Collector struct {
+	game.NopObserver
+
+	mu            sync.Mutex
+	gamesStarted  uint64
+	gamesPlayed   uint64
+	rounds        uint64
+	rolls         uint64
+	busts         uint64
+	undoneBanks   uint64 // Banks undone by a host, still counted in bankPoints
+	faults        map[game.FaultKind]uint64
+	bankPoints    *histogram
+	agentLatency  *histogram
+	agentDecision map[bool]uint64
+}
+
+/**
+ * Distribution of observed values, counted in cumulative buckets
+ */
+type histogram struct {
+	bounds []float64
+	counts []uint64 // Count of values at or below each bound
+	sum    float64
+	count  uint64
+}
+
+/**
+ * Creates a collector with nothing counted
+ *
+ * @return The collector
+ */
+func New() *Collector {
+	return &Collector{
+		faults:        make(map[game.FaultKind]uint64),
+		bankPoints:    newHistogram(BANK_POINTS_BUCKETS),
+		agentLatency:  newHistogram(LATENCY_BUCKETS),
+		agentDecision: make(map[bool]uint64),
+	}
+}
+
+func (c *Collector) OnGameStart(game.GameStartEvent) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.gamesStarted++
+}
+
+func (c *Collector) OnRoll(e game.RollEvent) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.rolls++
+	if e.Busted {
+		c.busts++
+	}
+}
+
+func (c *Collector) OnBank(e game.BankEvent) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.bankPoints.observe(float64(e.Points))
+}
+
+func (c *Collector) OnUndo(e game.UndoEvent) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.undoneBanks += uint64(len(e.Banks))
+}
+
+func (c *Collector) OnRoundEnd(game.RoundEndEvent) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.rounds++
+}
+
+func (c *Collector) OnGameEnd(game.GameEndEvent) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.gamesPlayed++
+}
+
+func (c *Collector) OnDecision(e game.DecisionEvent) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.agentLatency.observe(e.Latency.Seconds())
+	if e.Fault == "" {
+		c.agentDecision[e.Bank]++
+	}
+}
+
+func (c *Collector) OnFault(f game.Fault) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.faults[f.Kind]++
+}
+
+/**
+ * Writes every metric in the Prometheus text exposition format
+ *
+ * @param w Writer of the metrics
+ *
+ * @return An error if the metrics couldn't be written
+ */
+func (c *Collector) WritePrometheus(w io.Writer) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	p := &promWriter{w: w}
+	p.counter("bank_games_started_total", "Games started.", c.gamesStarted)
+	p.counter("bank_games_played_total", "Games played until their last round.", c.gamesPlayed)
+	p.counter("bank_rounds_total", "Rounds played.", c.rounds)
+	p.counter("bank_rolls_total", "Dice rolled, including the rolls that bust.", c.rolls)
+	p.counter("bank_busts_total", "Rounds ended by rolling a 7 after the safe rolls.", c.busts)
+	p.counter("bank_banks_undone_total", "Banks undone by the host, which bank_bank_points still counts.", c.undoneBanks)
+
+	p.header("bank_agent_decisions_total", "AI Agent decisions by whether they banked.", "counter")
+	for _, bank := range []bool{true, false} {
+		p.sample("bank_agent_decisions_total", `{bank="`+strconv.FormatBool(bank)+`"}`, float64(c.agentDecision[bank]))
+	}
+
+	p.header("bank_agent_faults_total", "AI Agent faults by kind.", "counter")
+	kinds := make([]string, 0, len(c.faults))
+	for kind := range c.faults {
+		kinds = append(kinds, string(kind))
+	}
+	sort.Strings(kinds)
+	for _, kind := range kinds {
+		p.sample("bank_agent_faults_total", `{kind="`+kind+`"}`, float64(c.faults[game.FaultKind(kind)]))
+	}
+
+	p.histogram("bank_bank_points", "Points banked by each player.", c.bankPoints)
+	p.histogram("bank_agent_decision_seconds", "Time AI Agents took to decide if they bank.", c.agentLatency)
+	return p.err
+}
+
+/**
+ * Creates a handler serving the metrics for Prometheus to scrape
+ *
+ * @return The handler
+ */
+func (c *Collector) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		c.WritePrometheus(w)
+	})
+}
+
+/**
+ * Summarizes the metrics on a single line
+ *
+ * @return The summary
+ */
+func (c *Collector) Summary() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	faults := uint64(0)
+	for _, count := range c.faults {
+		faults += count
+	}
+
+	return fmt.Sprintf("games=%d/%d rolls=%d busts=%d banks=%d undone=%d avg_bank=%.1f agent_decisions=%d avg_latency=%s faults=%d",
+		c.gamesPlayed, c.gamesStarted, c.rolls, c.busts,
+		c.bankPoints.count, c.undoneBanks, c.bankPoints.mean(),
+		c.agentLatency.count, time.Duration(c.agentLatency.mean()*float64(time.Second)).Round(time.Microsecond),
+		faults)
+}
+
+/**
+ * Writes the summary every interval until stopped
+ *
+ * @param w Writer of the summaries, such as stderr
+ * @param every Time between summaries
+ *
+ * @return Function that stops the summaries after writing a last one
+ */
+func (c *Collector) Report(w io.Writer, every time.Duration) func() {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(every)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				fmt.Fprintln(w, "metrics:", c.Summary())
+			case <-done:
+				fmt.Fprintln(w, "metrics:", c.Summary())
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			close(done)
+			<-stopped
+		})
+	}
+}
+
+/**
+ * Creates an empty histogram
+ *
+ * @param bounds Upper bounds of the buckets, in increasing order
+ *
+ * @return The histogram
+ */
+func newHistogram(bounds []float64) *histogram {
+	return &histogram{bounds: bounds, counts: make([]uint64, len(bounds))}
+}
+
+/**
+ * Counts a value in every bucket it fits in
+ *
+ * @param val Value observed
+ */
+func (h *histogram) observe(val float64) {
+	for idx, bound := range h.bounds {
+		if val <= bound {
+			h.counts[idx]++
+		}
+	}
+	h.sum += val
+	h.count++
+}
+
+/**
+ * Gets the average value observed
+ *
+ * @return The average, 0 if nothing was observed
+ */
+func (h *histogram) mean() float64 {
+	if h.count == 0 {
+		return 0
+	}
+	return h.sum / float64(h.count)
+}
+
+/**
+ * Writes metrics in the Prometheus text format, keeping the first error
+ */
+type promWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (p *promWriter) header(name string, help string, kind string) {
+	p.printf("# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+}
+
+func (p *promWriter) sample(name string, labels string, val float64) {
+	p.printf("%s%s %s\n", name, labels, strconv.FormatFloat(val, 'g', -1, 64))
+}
+
+func (p *promWriter) counter(name string, help string, val uint64) {
+	p.header(name, help, "counter")
+	p.sample(name, "", float64(val))
+}
+
+func (p *promWriter) histogram(name string, help string, h *histogram) {
+	p.header(name, help, "histogram")
+	for idx, bound := range h.bounds {
+		p.sample(name+"_bucket", `{le="`+strconv.FormatFloat(bound, 'g', -1, 64)+`"}`, float64(h.counts[idx]))
+	}
+	p.sample(name+"_bucket", `{le="+Inf"}`, float64(h.count))
+	p.sample(name+"_sum", "", h.sum)
+	p.sample(name+"_count", "", float64(h.count))
+}
+
+func (p *promWriter) printf(format string, args ...any) {
+	if p.err == nil {
+		_, p.err = fmt.Fprintf(p.w, format, args...)
+	}
+}
@@ -0,0 +1,251 @@
+package metrics
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Sparhawk96/bank-ais/game"
+)
+
+/**
+ * AI Agent that banks once the round points reach a threshold
+ */
+type thresholdAgent struct {
+	name      string
+	threshold uint
+}
+
+func (a thresholdAgent) Name() string {
+	return a.name
+}
+
+func (a thresholdAgent) Bank(g *game.Game) bool {
+	return a.threshold <= g.GetData(a).RoundPoints
+}
+
+func (a thresholdAgent) AiAgent() bool {
+	return true
+}
+
+/**
+ * AI Agent that panics every time it's asked to bank
+ */
+type panickingAgent struct {
+	name string
+}
+
+func (a panickingAgent) Name() string           { return a.name }
+func (a panickingAgent) Bank(g *game.Game) bool { panic("oops") }
+func (a panickingAgent) AiAgent() bool          { return true }
+
+/**
+ * Plays a seeded game between AI Agents
+ *
+ * @param t Test the game is played for
+ * @param observer Observer of the game
+ * @param seed Seed of the dice
+ * @param players Players of the game
+ *
+ * @return The rolls and busts of the game
+ */
+func playGame(t *testing.T, observer game.Observer, seed int64, players ...game.Player) (rolls int, busts int) {
+	t.Helper()
+
+	g := game.NewGame()
+	g.SetSeed(seed)
+	g.AddObserver(observer)
+	for _, player := range players {
+		if err := g.AddPlayer(player); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := g.Begin(); err != nil {
+		t.Fatal(err)
+	}
+
+	for !g.Over() {
+		roll, err := g.Roll()
+		if err != nil {
+			t.Fatal(err)
+		}
+		rolls++
+		if roll.Busted {
+			busts++
+		}
+	}
+	return rolls, busts
+}
+
+func TestCollectorCounts(t *testing.T) {
+	c := New()
+	rolls, busts := playGame(t, c, 42, thresholdAgent{"Steady", 150}, thresholdAgent{"Greedy", 400})
+
+	if c.gamesStarted != 1 || c.gamesPlayed != 1 {
+		t.Errorf("unexpected games: %d started, %d played", c.gamesStarted, c.gamesPlayed)
+	}
+	if c.rolls != uint64(rolls) || c.busts != uint64(busts) {
+		t.Errorf("got %d rolls and %d busts, expected %d and %d", c.rolls, c.busts, rolls, busts)
+	}
+	if c.rounds != game.MAX_ROUNDS {
+		t.Errorf("got %d rounds, expected %d", c.rounds, game.MAX_ROUNDS)
+	}
+	if c.bankPoints.count == 0 || c.agentLatency.count == 0 {
+		t.Error("expected the banks and agent decisions to be observed")
+	}
+	if decisions := c.agentDecision[true] + c.agentDecision[false]; decisions != c.agentLatency.count {
+		t.Errorf("got %d decisions, expected %d", decisions, c.agentLatency.count)
+	}
+}
+
+func TestCollectorFaults(t *testing.T) {
+	c := New()
+	playGame(t, c, 1, thresholdAgent{"Steady", 150}, panickingAgent{"Oops"})
+
+	if c.faults[game.FAULT_PANIC] == 0 {
+		t.Errorf("expected the panics to be counted, got %v", c.faults)
+	}
+	if out := c.Summary(); !strings.Contains(out, "faults=") || strings.Contains(out, "faults=0") {
+		t.Errorf("unexpected summary: %s", out)
+	}
+}
+
+func TestCollectorUndo(t *testing.T) {
+	c := New()
+	g := game.NewGame()
+	g.SetSeed(42)
+	g.AddObserver(c)
+	g.AddPlayer(game.NewHumanPlayer("Ann"))
+	g.AddPlayer(game.NewHumanPlayer("Bob"))
+	if err := g.SetHost("Ann"); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Begin(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := g.Roll(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := g.BankPlayer("Bob"); err != nil {
+		t.Fatal(err)
+	}
+	if c.bankPoints.count != 1 || c.bankPoints.sum == 0 {
+		t.Fatalf("expected Bob's bank to be observed, got %+v", c.bankPoints)
+	}
+	if _, err := g.Undo("Ann"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Counters only go up, so the undo is counted on its own
+	if c.bankPoints.count != 1 || c.undoneBanks != 1 {
+		t.Errorf("expected the bank to stay observed and the undo counted, got %+v and %d undone", c.bankPoints, c.undoneBanks)
+	}
+	if out := c.Summary(); !strings.Contains(out, "banks=1 undone=1") {
+		t.Errorf("unexpected summary: %s", out)
+	}
+}
+
+func TestCollectorManyGames(t *testing.T) {
+	c := New()
+
+	var wg sync.WaitGroup
+	for seed := int64(0); seed < 8; seed++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			playGame(t, c, seed, thresholdAgent{"Steady", 150})
+		}()
+	}
+	wg.Wait()
+
+	if c.gamesPlayed != 8 {
+		t.Errorf("got %d games, expected 8", c.gamesPlayed)
+	}
+}
+
+func TestWritePrometheus(t *testing.T) {
+	c := New()
+	for _, pts := range []uint{5, 60, 60, 5000} {
+		c.OnBank(game.BankEvent{Points: pts})
+	}
+	c.OnRoll(game.RollEvent{Busted: true})
+	c.OnUndo(game.UndoEvent{Undo: game.Undo{Banks: []game.BankEvent{{Points: 60}}}})
+	c.OnFault(game.Fault{Kind: game.FAULT_TIMEOUT})
+	c.OnDecision(game.DecisionEvent{Latency: 2 * time.Millisecond})
+
+	var buf bytes.Buffer
+	if err := c.WritePrometheus(&buf); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+
+	for _, line := range []string{
+		"# TYPE bank_rolls_total counter",
+		"bank_rolls_total 1",
+		"bank_busts_total 1",
+		"bank_banks_undone_total 1",
+		`bank_agent_faults_total{kind="timeout"} 1`,
+		`bank_agent_decisions_total{bank="false"} 1`,
+		"# TYPE bank_bank_points histogram",
+		`bank_bank_points_bucket{le="10"} 1`,
+		`bank_bank_points_bucket{le="100"} 3`,
+		`bank_bank_points_bucket{le="1600"} 3`,
+		`bank_bank_points_bucket{le="+Inf"} 4`,
+		"bank_bank_points_sum 5125",
+		"bank_bank_points_count 4",
+		`bank_agent_decision_seconds_bucket{le="0.001"} 0`,
+		`bank_agent_decision_seconds_bucket{le="0.005"} 1`,
+	} {
+		if !strings.Contains(out, line+"\n") {
+			t.Errorf("expected %q in:\n%s", line, out)
+		}
+	}
+}
+
+func TestHandler(t *testing.T) {
+	c := New()
+	c.OnGameEnd(game.GameEndEvent{})
+
+	rec := httptest.NewRecorder()
+	c.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain") {
+		t.Errorf("unexpected content type: %s", ct)
+	}
+	if !strings.Contains(rec.Body.String(), "bank_games_played_total 1\n") {
+		t.Errorf("unexpected metrics:\n%s", rec.Body.String())
+	}
+}
+
+/**
+ * Writer that is safe to write to from the reporting goroutine
+ */
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func TestReport(t *testing.T) {
+	var out syncBuffer
+	c := New()
+	stop := c.Report(&out, time.Hour)
+	c.OnRoll(game.RollEvent{})
+	stop()
+	stop() // Stopping twice is harmless
+
+	if got := out.buf.String(); got != "metrics: "+c.Summary()+"\n" {
+		t.Errorf("expected one last summary, got %q", got)
+	}
+	if !strings.Contains(c.Summary(), "rolls=1 ") {
+		t.Errorf("unexpected summary: %s", c.Summary())
+	}
+}
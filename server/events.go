@@ -0,0 +1,149 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/Sparhawk96/bank-ais/game"
+)
+
+// Events buffered per subscriber before newer events are dropped for them
+const EVENT_BUFFER = 64
+
+type sseEvent struct {
+	name string
+	data []byte
+}
+
+/**
+ * Game observer that fans game events out to Server-Sent Event subscribers
+ */
+type broker struct {
+	mu     sync.Mutex
+	subs   map[chan sseEvent]struct{}
+	done   chan struct{} // Closed once the game is removed
+	closed bool
+}
+
+func newBroker() *broker {
+	return &broker{subs: make(map[chan sseEvent]struct{}), done: make(chan struct{})}
+}
+
+/**
+ * Ends every subscriber's stream, as the game was removed
+ */
+func (b *broker) close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.closed {
+		b.closed = true
+		close(b.done)
+	}
+}
+
+/**
+ * Subscribes to the game's events
+ *
+ * @return Channel of events and a function to unsubscribe
+ */
+func (b *broker) subscribe() (<-chan sseEvent, func()) {
+	ch := make(chan sseEvent, EVENT_BUFFER)
+
+	b.mu.Lock()
+	b.subs[ch] = struct{}{}
+	b.mu.Unlock()
+
+	return ch, func() {
+		b.mu.Lock()
+		delete(b.subs, ch)
+		b.mu.Unlock()
+	}
+}
+
+/**
+ * Sends an event to every subscriber without blocking the game
+ *
+ * @param name SSE event name
+ * @param payload Event encoded as the JSON data
+ */
+func (b *broker) publish(name string, payload any) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for ch := range b.subs {
+		select {
+		case ch <- sseEvent{name, data}:
+		default: // Slow subscriber, drop the event
+		}
+	}
+}
+
+func (b *broker) OnGameStart(e game.GameStartEvent) { b.publish("start", e) }
+func (b *broker) OnRoll(e game.RollEvent)           { b.publish("roll", e) }
+func (b *broker) OnBank(e game.BankEvent)           { b.publish("bank", e) }
+func (b *broker) OnRoundEnd(e game.RoundEndEvent)   { b.publish("round", e) }
+func (b *broker) OnGameEnd(e game.GameEndEvent)     { b.publish("end", e) }
+func (b *broker) OnUndo(e game.UndoEvent)           { b.publish("undo", e) }
+func (b *broker) OnSeatChange(e game.SeatEvent)     { b.publish("seat", e) }
+func (b *broker) OnDecision(e game.DecisionEvent)   { b.publish("decision", e) }
+func (b *broker) OnFault(e game.Fault)              { b.publish("fault", e) }
+
+/**
+ * Streams the game's events as Server-Sent Events until the client disconnects
+ * or the game is removed. The current state is sent first as a "state" event.
+ */
+func (s *Server) streamEvents(w http.ResponseWriter, r *http.Request, hg *hostedGame) {
+	flusher, canFlush := w.(http.Flusher)
+	if !canFlush {
+		writeError(w, http.StatusInternalServerError, fmt.Errorf("streaming isn't supported"))
+		return
+	}
+
+	events, unsubscribe := hg.events.subscribe()
+	defer unsubscribe()
+
+	// The game isn't idle while it's watched
+	s.mu.Lock()
+	hg.watchers++
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		hg.watchers--
+		hg.lastUsed = s.now()
+		s.mu.Unlock()
+	}()
+
+	hg.mu.Lock()
+	state, _ := json.Marshal(hg.state())
+	hg.mu.Unlock()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	writeEvent(w, sseEvent{"state", state})
+	flusher.Flush()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-hg.events.done:
+			return
+		case event := <-events:
+			writeEvent(w, event)
+			flusher.Flush()
+		}
+	}
+}
+
+func writeEvent(w http.ResponseWriter, event sseEvent) {
+	fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.name, event.data)
+}
@@ -0,0 +1,495 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/Sparhawk96/bank-ais/agents"
+	"github.com/Sparhawk96/bank-ais/game"
+)
+
+// Round points AI Agents bank at when none is requested
+const DEFAULT_AI_BANK_AT = 150
+
+// Time a game is kept without any requests before it's removed
+const DEFAULT_GAME_TTL = time.Hour
+
+// Time between looking for idle games to remove
+const EVICT_INTERVAL = time.Minute
+
+/**
+ * HTTP API for hosting games of Bank.
+ *
+ *   POST /api/games                            Creates a game
+ *   GET  /api/games/{id}                       Gets the game state
+ *   DELETE /api/games/{id}                     Removes the game, ending its event streams
+ *   POST /api/games/{id}/players               Adds a human or AI player, who joins at the next round once started
+ *   POST /api/games/{id}/start                 Starts the game
+ *   POST /api/games/{id}/roll                  Rolls the dice
+ *   POST /api/games/{id}/players/{name}/bank   Banks for a human player
+ *   POST /api/games/{id}/players/{name}/undo   Undoes the last bank, only for the host
+ *   POST /api/games/{id}/players/{name}/leave  A player forfeits, or an AI Agent takes over their seat
+ *   GET  /api/games/{id}/events                Server-Sent Events of the game
+ */
+type Server struct {
+	mu     sync.Mutex
+	games  map[string]*hostedGame
+	nextID int
+	mux    *http.ServeMux
+	logger *slog.Logger // Traces every game hosted, nil to not trace
+	ttl    time.Duration
+	now    func() time.Time
+	done   chan struct{} // Closed to stop removing idle games
+	closed sync.Once
+
+	observers []game.Observer // Observe every game hosted
+}
+
+type hostedGame struct {
+	mu       sync.Mutex // Guards the game, it isn't safe for concurrent use
+	id       string
+	game     *game.Game
+	events   *broker
+	lastUsed time.Time // Time of the last request for the game, guarded by the server's lock
+	watchers int       // Open event streams of the game, guarded by the server's lock
+}
+
+type GameState struct {
+	ID      string   `json:"id"`
+	Started bool     `json:"started"`
+	Over    bool     `json:"over"`
+	Winners []string `json:"winners,omitempty"`
+	game.BankDataSnapshot
+}
+
+type RollResponse struct {
+	Roll  game.RollEvent `json:"roll"`
+	State GameState      `json:"state"`
+}
+
+type createGameRequest struct {
+	Seed *int64 `json:"seed"`
+
+	Rounds         int  `json:"rounds"`         // Rounds played, 0 for the mode's default
+	TargetScore    uint `json:"targetScore"`    // Score that ends the game, 0 for no target
+	EliminateEvery int  `json:"eliminateEvery"` // Rounds between knocking out the lowest scorer, 0 for none
+
+	AgentTimeout string `json:"agentTimeout"` // Time AI Agents have to decide such as "500ms", game default if empty
+	MaxFaults    *int   `json:"maxFaults"`    // Faults that disqualify an AI Agent, 0 to never disqualify
+}
+
+type addPlayerRequest struct {
+	Name   string `json:"name"`
+	AI     bool   `json:"ai"`
+	BankAt uint   `json:"bankAt"` // Round points an AI Agent banks at
+
+	Handicap game.Handicap `json:"handicap"`
+	Host     bool          `json:"host"` // True if the human player can undo banks
+
+	StartingPoints uint `json:"startingPoints"` // Points a player joining a started game starts with
+}
+
+type leaveRequest struct {
+	StandIn bool `json:"standIn"` // True if an AI Agent takes over the seat instead of forfeiting
+	BankAt  uint `json:"bankAt"`  // Round points the AI Agent banks at
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+/**
+ * Creates the HTTP API server
+ *
+ * @return The server, which is an http.Handler, close it to stop removing idle games
+ */
+func New() *Server {
+	s := &Server{
+		games: make(map[string]*hostedGame),
+		mux:   http.NewServeMux(),
+		ttl:   DEFAULT_GAME_TTL,
+		now:   time.Now,
+		done:  make(chan struct{}),
+	}
+	go s.evictEvery(EVICT_INTERVAL)
+
+	s.mux.HandleFunc("POST /api/games", s.createGame)
+	s.mux.HandleFunc("GET /api/games/{id}", s.withGame(s.getGame))
+	s.mux.HandleFunc("DELETE /api/games/{id}", s.withGame(s.deleteGame))
+	s.mux.HandleFunc("POST /api/games/{id}/players", s.withGame(s.addPlayer))
+	s.mux.HandleFunc("POST /api/games/{id}/start", s.withGame(s.startGame))
+	s.mux.HandleFunc("POST /api/games/{id}/roll", s.withGame(s.roll))
+	s.mux.HandleFunc("POST /api/games/{id}/players/{name}/bank", s.withGame(s.bank))
+	s.mux.HandleFunc("POST /api/games/{id}/players/{name}/undo", s.withGame(s.undo))
+	s.mux.HandleFunc("POST /api/games/{id}/players/{name}/leave", s.withGame(s.leave))
+	s.mux.HandleFunc("GET /api/games/{id}/events", s.withGame(s.streamEvents))
+
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+/**
+ * Sets the logger that traces the games created from now on
+ *
+ * @param logger Logger to trace the games with, each game's ID is added to its records
+ */
+func (s *Server) SetLogger(logger *slog.Logger) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.logger = logger
+}
+
+/**
+ * Sets how long a game is kept without any requests. Idle games are removed
+ * every EVICT_INTERVAL and when a game is created, a game with an open event
+ * stream is never idle.
+ *
+ * @param ttl Time a game is kept after its last request, 0 or less to keep games until deleted
+ */
+func (s *Server) SetGameTTL(ttl time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ttl = ttl
+}
+
+/**
+ * Adds an observer to every game created from now on, such as a metrics collector
+ *
+ * @param observer Observer to add, it must be safe to call from many games at once
+ */
+func (s *Server) AddObserver(observer game.Observer) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.observers = append(s.observers, observer)
+}
+
+/**
+ * Registers an extra handler, such as a front-end, on the server's mux
+ *
+ * @param pattern http.ServeMux pattern to handle
+ * @param handler Handler for the pattern
+ */
+func (s *Server) Handle(pattern string, handler http.Handler) {
+	s.mux.Handle(pattern, handler)
+}
+
+/**
+ * Looks up the game in the request path before calling the handler
+ *
+ * @param handler Handler needing the game
+ *
+ * @return Handler that responds with 404 if the game doesn't exist
+ */
+func (s *Server) withGame(handler func(http.ResponseWriter, *http.Request, *hostedGame)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		hg, has := s.games[r.PathValue("id")]
+		if has {
+			hg.lastUsed = s.now()
+		}
+		s.mu.Unlock()
+
+		if !has {
+			writeError(w, http.StatusNotFound, fmt.Errorf("no game with id: '%s'", r.PathValue("id")))
+			return
+		}
+		handler(w, r, hg)
+	}
+}
+
+func (s *Server) createGame(w http.ResponseWriter, r *http.Request) {
+	var req createGameRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	g := game.NewGame()
+	if req.Seed != nil {
+		g.SetSeed(*req.Seed)
+	}
+	if err := configureGame(g, req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	events := newBroker()
+	g.AddObserver(events)
+
+	s.mu.Lock()
+	s.evictIdle()
+	s.nextID++
+	hg := &hostedGame{id: fmt.Sprint(s.nextID), game: g, events: events, lastUsed: s.now()}
+	if s.logger != nil {
+		g.SetLogger(s.logger.With("game", hg.id))
+	}
+	for _, observer := range s.observers {
+		g.AddObserver(observer)
+	}
+	s.games[hg.id] = hg
+	s.mu.Unlock()
+
+	hg.mu.Lock()
+	defer hg.mu.Unlock()
+	writeJSON(w, http.StatusCreated, hg.state())
+}
+
+/**
+ * Sets up how a new game is played from the create request
+ *
+ * @param g Game that was created
+ * @param req Request to create the game
+ *
+ * @return An error if the mode, timeout or max faults aren't valid
+ */
+func configureGame(g *game.Game, req createGameRequest) error {
+	mode := game.Mode{Rounds: req.Rounds, TargetScore: req.TargetScore, EliminateEvery: req.EliminateEvery}
+	if err := g.SetMode(mode); err != nil {
+		return err
+	}
+
+	if req.AgentTimeout != "" {
+		timeout, err := time.ParseDuration(req.AgentTimeout)
+		if err != nil {
+			return fmt.Errorf("invalid agent timeout: %w", err)
+		}
+		g.SetAgentTimeout(timeout)
+	}
+
+	if req.MaxFaults != nil {
+		if *req.MaxFaults < 0 {
+			return fmt.Errorf("invalid max faults: %d", *req.MaxFaults)
+		}
+		g.SetMaxFaults(*req.MaxFaults)
+	}
+	return nil
+}
+
+/**
+ * Stops removing idle games, the games hosted are kept
+ */
+func (s *Server) Close() {
+	s.closed.Do(func() { close(s.done) })
+}
+
+/**
+ * Removes the idle games every interval until the server is closed
+ *
+ * @param every Time between looking for idle games
+ */
+func (s *Server) evictEvery(every time.Duration) {
+	ticker := time.NewTicker(every)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.mu.Lock()
+			s.evictIdle()
+			s.mu.Unlock()
+		case <-s.done:
+			return
+		}
+	}
+}
+
+/**
+ * Removes the games that haven't had a request within the TTL,
+ * games being watched are used until their last event stream closes
+ *
+ * @note The server's lock must be held
+ */
+func (s *Server) evictIdle() {
+	if s.ttl <= 0 {
+		return
+	}
+	for id, hg := range s.games {
+		if hg.watchers > 0 {
+			hg.lastUsed = s.now()
+		} else if s.now().Sub(hg.lastUsed) > s.ttl {
+			delete(s.games, id)
+			hg.events.close()
+		}
+	}
+}
+
+func (s *Server) deleteGame(w http.ResponseWriter, r *http.Request, hg *hostedGame) {
+	s.mu.Lock()
+	delete(s.games, hg.id)
+	s.mu.Unlock()
+
+	hg.events.close()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) getGame(w http.ResponseWriter, r *http.Request, hg *hostedGame) {
+	hg.mu.Lock()
+	defer hg.mu.Unlock()
+	writeJSON(w, http.StatusOK, hg.state())
+}
+
+func (s *Server) addPlayer(w http.ResponseWriter, r *http.Request, hg *hostedGame) {
+	var req addPlayerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	} else if req.Name == "" {
+		writeError(w, http.StatusBadRequest, errors.New("player name is required"))
+		return
+	}
+
+	player := game.NewHumanPlayer(req.Name)
+	if req.AI {
+		if req.BankAt == 0 {
+			req.BankAt = DEFAULT_AI_BANK_AT
+		}
+		player = agents.NewThreshold(req.Name, req.BankAt)
+	}
+
+	hg.mu.Lock()
+	defer hg.mu.Unlock()
+	if hg.game.Started() {
+		// Only chosen before the game starts, a player joining late gets starting points instead
+		if req.Host || req.Handicap != (game.Handicap{}) {
+			writeError(w, http.StatusConflict, errors.New("players joining a started game can't be the host or have a handicap"))
+			return
+		}
+		if err := hg.game.Join(player, req.StartingPoints); err != nil {
+			writeError(w, http.StatusConflict, err)
+			return
+		}
+	} else if err := hg.game.AddPlayer(player, req.Handicap); err != nil {
+		writeError(w, http.StatusConflict, err)
+		return
+	} else if req.Host {
+		if err := hg.game.SetHost(req.Name); err != nil {
+			writeError(w, http.StatusConflict, err)
+			return
+		}
+	}
+	writeJSON(w, http.StatusCreated, hg.state())
+}
+
+func (s *Server) startGame(w http.ResponseWriter, r *http.Request, hg *hostedGame) {
+	hg.mu.Lock()
+	defer hg.mu.Unlock()
+	if err := hg.game.Begin(); err != nil {
+		writeError(w, http.StatusConflict, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, hg.state())
+}
+
+func (s *Server) roll(w http.ResponseWriter, r *http.Request, hg *hostedGame) {
+	hg.mu.Lock()
+	defer hg.mu.Unlock()
+	roll, err := hg.game.Roll()
+	if err != nil {
+		writeError(w, http.StatusConflict, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, RollResponse{Roll: roll, State: hg.state()})
+}
+
+func (s *Server) bank(w http.ResponseWriter, r *http.Request, hg *hostedGame) {
+	name := r.PathValue("name")
+
+	hg.mu.Lock()
+	defer hg.mu.Unlock()
+
+	found := false
+	for _, player := range hg.game.State().Players {
+		found = found || player.Name == name
+	}
+	if !found {
+		writeError(w, http.StatusNotFound, fmt.Errorf("no player with that name: '%s'", name))
+		return
+	}
+
+	if err := hg.game.BankPlayer(name); err != nil {
+		writeError(w, http.StatusConflict, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, hg.state())
+}
+
+func (s *Server) undo(w http.ResponseWriter, r *http.Request, hg *hostedGame) {
+	hg.mu.Lock()
+	defer hg.mu.Unlock()
+	if _, err := hg.game.Undo(r.PathValue("name")); err != nil {
+		writeError(w, http.StatusConflict, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, hg.state())
+}
+
+func (s *Server) leave(w http.ResponseWriter, r *http.Request, hg *hostedGame) {
+	var req leaveRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	name := r.PathValue("name")
+	hg.mu.Lock()
+	defer hg.mu.Unlock()
+
+	var err error
+	if req.StandIn {
+		if req.BankAt == 0 {
+			req.BankAt = DEFAULT_AI_BANK_AT
+		}
+		err = hg.game.Replace(name, agents.NewThreshold(name, req.BankAt))
+	} else {
+		err = hg.game.Forfeit(name)
+	}
+
+	if err != nil {
+		writeError(w, http.StatusConflict, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, hg.state())
+}
+
+/**
+ * Gets the state of a hosted game
+ *
+ * @note The game's lock must be held
+ *
+ * @return The game state
+ */
+func (hg *hostedGame) state() GameState {
+	state := GameState{
+		ID:               hg.id,
+		Started:          hg.game.Started(),
+		Over:             hg.game.Over(),
+		BankDataSnapshot: hg.game.State(),
+	}
+
+	if state.Over {
+		state.Winners = hg.game.Winners()
+	}
+	return state
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, errorResponse{Error: err.Error()})
+}
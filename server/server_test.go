@@ -0,0 +1,428 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Sparhawk96/bank-ais/game"
+)
+
+/**
+ * Sends a request to the server and decodes the JSON response
+ *
+ * @return The response status code
+ */
+func do(t *testing.T, s http.Handler, method, path, body string, out any) int {
+	t.Helper()
+
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+
+	if out != nil {
+		if err := json.NewDecoder(rec.Body).Decode(out); err != nil {
+			t.Fatalf("%s %s: decoding %q: %v", method, path, rec.Body.String(), err)
+		}
+	}
+	return rec.Code
+}
+
+func TestPlayGame(t *testing.T) {
+	s := New()
+
+	var state GameState
+	if code := do(t, s, "POST", "/api/games", `{"seed": 42}`, &state); code != http.StatusCreated {
+		t.Fatalf("create game: got %d", code)
+	}
+	id := state.ID
+	if state.Seed != 42 || state.Started {
+		t.Fatalf("unexpected new game: %+v", state)
+	}
+
+	if code := do(t, s, "POST", "/api/games/"+id+"/players", `{"name": "Ann"}`, &state); code != http.StatusCreated {
+		t.Fatalf("add human: got %d", code)
+	}
+	if code := do(t, s, "POST", "/api/games/"+id+"/players", `{"name": "Bot", "ai": true, "bankAt": 100}`, &state); code != http.StatusCreated {
+		t.Fatalf("add AI: got %d", code)
+	}
+	if code := do(t, s, "POST", "/api/games/"+id+"/players", `{"name": "Ann"}`, nil); code != http.StatusConflict {
+		t.Errorf("duplicate player: got %d", code)
+	}
+	if code := do(t, s, "POST", "/api/games/"+id+"/roll", "", nil); code != http.StatusConflict {
+		t.Errorf("roll before start: got %d", code)
+	}
+
+	if code := do(t, s, "POST", "/api/games/"+id+"/start", "", &state); code != http.StatusOK || !state.Started {
+		t.Fatalf("start: got %d %+v", code, state)
+	}
+	if code := do(t, s, "POST", "/api/games/"+id+"/players/Bot/bank", "", nil); code != http.StatusConflict {
+		t.Errorf("banking for an AI Agent: got %d", code)
+	}
+	if code := do(t, s, "POST", "/api/games/"+id+"/players/Nobody/bank", "", nil); code != http.StatusNotFound {
+		t.Errorf("banking for an unknown player: got %d", code)
+	}
+
+	// Ann banks as soon as the round has 50 points
+	for rolls := 0; !state.Over; rolls++ {
+		if rolls > 10000 {
+			t.Fatal("game never ended")
+		}
+
+		var res RollResponse
+		if code := do(t, s, "POST", "/api/games/"+id+"/roll", "", &res); code != http.StatusOK {
+			t.Fatalf("roll: got %d", code)
+		}
+		state = res.State
+
+		if !res.Roll.Busted && res.Roll.Points >= 50 && !state.Over {
+			for _, player := range state.Players {
+				if player.Name == "Ann" && !player.Banked && state.RollNumber > 0 {
+					if code := do(t, s, "POST", "/api/games/"+id+"/players/Ann/bank", "", &state); code != http.StatusOK {
+						t.Fatalf("bank: got %d", code)
+					}
+				}
+			}
+		}
+	}
+
+	if len(state.Winners) == 0 {
+		t.Errorf("expected winners once over: %+v", state)
+	}
+	if code := do(t, s, "POST", "/api/games/"+id+"/roll", "", nil); code != http.StatusConflict {
+		t.Errorf("roll after the game is over: got %d", code)
+	}
+
+	var fetched GameState
+	if code := do(t, s, "GET", "/api/games/"+id, "", &fetched); code != http.StatusOK || !fetched.Over {
+		t.Errorf("get game: got %d %+v", code, fetched)
+	}
+}
+
+func TestUnknownGame(t *testing.T) {
+	var res errorResponse
+	if code := do(t, New(), "GET", "/api/games/99", "", &res); code != http.StatusNotFound || res.Error == "" {
+		t.Errorf("got %d %+v", code, res)
+	}
+}
+
+func TestBadRequests(t *testing.T) {
+	s := New()
+	var state GameState
+	do(t, s, "POST", "/api/games", "", &state)
+
+	if code := do(t, s, "POST", "/api/games", "{", nil); code != http.StatusBadRequest {
+		t.Errorf("bad JSON: got %d", code)
+	}
+	if code := do(t, s, "POST", "/api/games/"+state.ID+"/players", `{"ai": true}`, nil); code != http.StatusBadRequest {
+		t.Errorf("missing name: got %d", code)
+	}
+}
+
+func TestEventStream(t *testing.T) {
+	s := New()
+	ts := httptest.NewServer(s)
+	defer ts.Close()
+
+	var state GameState
+	do(t, s, "POST", "/api/games", `{"seed": 7}`, &state)
+	do(t, s, "POST", "/api/games/"+state.ID+"/players", `{"name": "Ann"}`, nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, "GET", ts.URL+"/api/games/"+state.ID+"/events", nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("unexpected content type: %s", ct)
+	}
+
+	lines := bufio.NewScanner(resp.Body)
+	next := func() (string, string) {
+		var name, data string
+		for lines.Scan() {
+			line := lines.Text()
+			switch {
+			case strings.HasPrefix(line, "event: "):
+				name = strings.TrimPrefix(line, "event: ")
+			case strings.HasPrefix(line, "data: "):
+				data = strings.TrimPrefix(line, "data: ")
+			case line == "":
+				return name, data
+			}
+		}
+		t.Fatalf("stream ended: %v", lines.Err())
+		return "", ""
+	}
+
+	if name, _ := next(); name != "state" {
+		t.Fatalf("expected the state first, got %s", name)
+	}
+
+	do(t, s, "POST", "/api/games/"+state.ID+"/start", "", nil)
+	do(t, s, "POST", "/api/games/"+state.ID+"/roll", "", nil)
+	do(t, s, "POST", "/api/games/"+state.ID+"/players/Ann/bank", "", nil)
+
+	expected := []string{"start", "roll", "bank", "round"}
+	for _, want := range expected {
+		name, data := next()
+		if name != want {
+			t.Fatalf("expected a %s event, got %s %s", want, name, data)
+		}
+		if want == "bank" && !bytes.Contains([]byte(data), []byte(`"player":"Ann"`)) {
+			t.Errorf("unexpected bank event: %s", data)
+		}
+	}
+}
+
+func TestUndo(t *testing.T) {
+	s := New()
+	var state GameState
+	do(t, s, "POST", "/api/games", `{"seed": 42}`, &state)
+	id := state.ID
+	do(t, s, "POST", "/api/games/"+id+"/players", `{"name": "Ann", "host": true}`, nil)
+	do(t, s, "POST", "/api/games/"+id+"/players", `{"name": "Bob"}`, nil)
+	do(t, s, "POST", "/api/games/"+id+"/start", "", nil)
+
+	// Seed 42 doesn't bust on the first roll
+	do(t, s, "POST", "/api/games/"+id+"/roll", "", nil)
+	do(t, s, "POST", "/api/games/"+id+"/players/Bob/bank", "", nil)
+
+	if code := do(t, s, "POST", "/api/games/"+id+"/players/Bob/undo", "", nil); code != http.StatusConflict {
+		t.Errorf("undo by someone other than the host: got %d", code)
+	}
+	if code := do(t, s, "POST", "/api/games/"+id+"/players/Ann/undo", "", &state); code != http.StatusOK {
+		t.Fatalf("undo: got %d", code)
+	}
+	for _, player := range state.Players {
+		if player.Banked || player.Points != 0 {
+			t.Errorf("expected Bob's bank undone: %+v", player)
+		}
+	}
+}
+
+func TestJoinAndLeave(t *testing.T) {
+	s := New()
+	var state GameState
+	do(t, s, "POST", "/api/games", `{"seed": 42}`, &state)
+	id := state.ID
+	do(t, s, "POST", "/api/games/"+id+"/players", `{"name": "Ann"}`, nil)
+	do(t, s, "POST", "/api/games/"+id+"/players", `{"name": "Bob"}`, nil)
+	do(t, s, "POST", "/api/games/"+id+"/start", "", nil)
+
+	// Nothing has been rolled, so Cat joins right away
+	if code := do(t, s, "POST", "/api/games/"+id+"/players", `{"name": "Cat", "startingPoints": 40}`, &state); code != http.StatusCreated {
+		t.Fatalf("join: got %d", code)
+	}
+	for _, body := range []string{`{"name": "Dan", "host": true}`, `{"name": "Dan", "handicap": {"multiplier": 2}}`} {
+		if code := do(t, s, "POST", "/api/games/"+id+"/players", body, nil); code != http.StatusConflict {
+			t.Errorf("%s: expected a conflict joining late, got %d", body, code)
+		}
+	}
+	if code := do(t, s, "POST", "/api/games/"+id+"/players/Ann/leave", `{"standIn": true}`, &state); code != http.StatusOK {
+		t.Fatalf("stand in: got %d", code)
+	}
+	if code := do(t, s, "POST", "/api/games/"+id+"/players/Bob/leave", "", &state); code != http.StatusOK {
+		t.Fatalf("forfeit: got %d", code)
+	}
+	if code := do(t, s, "POST", "/api/games/"+id+"/players/Bob/leave", "", nil); code != http.StatusConflict {
+		t.Errorf("leaving twice: got %d", code)
+	}
+
+	seats := make(map[string]string)
+	for _, player := range state.Players {
+		seats[player.Name] = fmt.Sprintf("%d %t %t %t", player.Points, player.AiAgent, player.StandIn, player.Forfeited)
+	}
+	if len(seats) != 3 || seats["Cat"] != "40 false false false" || seats["Ann"] != "0 true true false" || seats["Bob"] != "0 false false true" {
+		t.Errorf("unexpected seats: %v", seats)
+	}
+}
+
+/**
+ * Observer counting the games started, safe for many games at once
+ */
+type startCounter struct {
+	game.NopObserver
+	mu     sync.Mutex
+	starts int
+}
+
+func (c *startCounter) OnGameStart(game.GameStartEvent) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.starts++
+}
+
+func TestObserveEveryGame(t *testing.T) {
+	s := New()
+	counter := new(startCounter)
+	s.AddObserver(counter)
+
+	for range 2 {
+		var state GameState
+		do(t, s, "POST", "/api/games", "", &state)
+		do(t, s, "POST", "/api/games/"+state.ID+"/players", `{"name": "Ann"}`, nil)
+		do(t, s, "POST", "/api/games/"+state.ID+"/start", "", nil)
+	}
+
+	if counter.starts != 2 {
+		t.Errorf("got %d games started, expected 2", counter.starts)
+	}
+}
+
+func TestCreateGameOptions(t *testing.T) {
+	s := New()
+
+	var state GameState
+	body := `{"rounds": 5, "targetScore": 300, "agentTimeout": "250ms", "maxFaults": 2}`
+	if code := do(t, s, "POST", "/api/games", body, &state); code != http.StatusCreated {
+		t.Fatalf("create game: got %d", code)
+	}
+	if state.MaxRounds != 5 || state.TargetScore != 300 {
+		t.Errorf("expected the requested mode, got %+v", state)
+	}
+
+	for _, body := range []string{
+		`{"rounds": -1}`,
+		`{"rounds": 1000}`,
+		`{"agentTimeout": "soon"}`,
+		`{"maxFaults": -1}`,
+	} {
+		var res errorResponse
+		if code := do(t, s, "POST", "/api/games", body, &res); code != http.StatusBadRequest || res.Error == "" {
+			t.Errorf("%s: got %d %+v", body, code, res)
+		}
+	}
+}
+
+func TestDeleteGame(t *testing.T) {
+	s := New()
+	ts := httptest.NewServer(s)
+	defer ts.Close()
+
+	var state GameState
+	do(t, s, "POST", "/api/games", "", &state)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, "GET", ts.URL+"/api/games/"+state.ID+"/events", nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if code := do(t, s, "DELETE", "/api/games/"+state.ID, "", nil); code != http.StatusNoContent {
+		t.Fatalf("delete game: got %d", code)
+	}
+	if code := do(t, s, "GET", "/api/games/"+state.ID, "", nil); code != http.StatusNotFound {
+		t.Errorf("get deleted game: got %d", code)
+	}
+	if code := do(t, s, "DELETE", "/api/games/"+state.ID, "", nil); code != http.StatusNotFound {
+		t.Errorf("delete twice: got %d", code)
+	}
+
+	// The event stream ends once the game is removed
+	lines := bufio.NewScanner(resp.Body)
+	for lines.Scan() {
+	}
+	if err := lines.Err(); err != nil {
+		t.Errorf("expected the stream to end, got %v", err)
+	}
+}
+
+func TestEvictIdleGames(t *testing.T) {
+	s := New()
+	defer s.Close()
+	s.mu.Lock()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	s.now = func() time.Time { return now }
+	s.mu.Unlock()
+	s.SetGameTTL(time.Minute)
+
+	// Moves the clock forward, guarded as the evictions read it
+	advance := func(d time.Duration) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		now = now.Add(d)
+	}
+	hosted := func(id string) bool {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		_, has := s.games[id]
+		return has
+	}
+	watchers := func(id string) int {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return s.games[id].watchers
+	}
+	waitFor := func(what string, done func() bool) {
+		t.Helper()
+		for deadline := time.Now().Add(2 * time.Second); !done(); time.Sleep(time.Millisecond) {
+			if time.Now().After(deadline) {
+				t.Fatalf("timed out waiting for %s", what)
+			}
+		}
+	}
+
+	var idle, active, watched GameState
+	do(t, s, "POST", "/api/games", "", &idle)
+	do(t, s, "POST", "/api/games", "", &active)
+	do(t, s, "POST", "/api/games", "", &watched)
+
+	// Someone keeps watching a game's events without sending requests
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	streamed := make(chan struct{})
+	go func() {
+		defer close(streamed)
+		req := httptest.NewRequest("GET", "/api/games/"+watched.ID+"/events", nil).WithContext(ctx)
+		s.ServeHTTP(httptest.NewRecorder(), req)
+	}()
+	waitFor("the stream to open", func() bool { return watchers(watched.ID) == 1 })
+
+	advance(40 * time.Second)
+	do(t, s, "GET", "/api/games/"+active.ID, "", nil)
+	advance(40 * time.Second)
+
+	// Idle games are removed without waiting for a game to be created
+	go s.evictEvery(time.Millisecond)
+	waitFor("the idle game to be removed", func() bool { return !hosted(idle.ID) })
+	if !hosted(active.ID) || !hosted(watched.ID) {
+		t.Error("expected the active and watched games to be kept")
+	}
+
+	// Once nobody watches, the game is idle from when the stream closed
+	cancel()
+	<-streamed
+	advance(40 * time.Second)
+	time.Sleep(10 * time.Millisecond)
+	if !hosted(watched.ID) {
+		t.Error("expected the watched game to be kept within the TTL of its stream closing")
+	}
+	advance(40 * time.Second)
+	waitFor("the unwatched game to be removed", func() bool { return !hosted(watched.ID) })
+
+	// A TTL of 0 keeps every game
+	var kept GameState
+	do(t, s, "POST", "/api/games", "", &kept)
+	s.SetGameTTL(0)
+	advance(24 * time.Hour)
+	time.Sleep(10 * time.Millisecond)
+	if !hosted(kept.ID) {
+		t.Error("expected games to be kept without a TTL")
+	}
+}
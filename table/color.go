@@ -0,0 +1,223 @@
+package table
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+)
+
+type Color int
+
+const (
+	DEFAULT_COLOR Color = iota
+	BLACK
+	RED
+	GREEN
+	YELLOW
+	BLUE
+	MAGENTA
+	CYAN
+	WHITE
+)
+
+/**
+ * ANSI styling applied to headers, rows, columns or single cells.
+ *
+ * @note When a cell has multiple highlights the more specific one wins
+ *       for each attribute: cell, then row, then column.
+ */
+type Highlight struct {
+	Foreground Color
+	Background Color
+	Bold       bool
+	Dim        bool
+}
+
+type ColorMode int
+
+const (
+	AUTO_COLORS   ColorMode = iota // Colors if the destination supports them and NO_COLOR isn't set
+	ALWAYS_COLORS                  // Colors even if NO_COLOR is set
+	NEVER_COLORS                   // No colors
+)
+
+/**
+ * A cell value with its own highlight
+ */
+type Cell struct {
+	Value     any
+	Highlight Highlight
+}
+
+/**
+ * True if ANSI colors should be printed when a table is rendered to a string
+ * with AUTO_COLORS, as there is no writer to detect them from.
+ *
+ * @note Defaults to whether stdout supports colors. NO_COLOR is also
+ *       checked every time a table is rendered.
+ */
+var ColorsEnabled = ColorsFor(os.Stdout)
+
+var ansiEscape = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+/**
+ * Wraps a value with a highlight to be used as a cell
+ *
+ * @param val Value of the cell
+ * @param h Highlight of the cell
+ *
+ * @return The highlighted cell
+ */
+func Highlighted(val any, h Highlight) Cell {
+	return Cell{Value: val, Highlight: h}
+}
+
+/**
+ * Adds a highlighted row to the table.
+ *
+ * @param row Contents of the row. Keys = Header Name, Values = Column Value
+ * @param h Highlight for every cell in the row
+ *
+ * @return An error is returned if one or more Keys refer to unspecified columns
+ */
+func (t *Table) AddHighlightedEntry(row map[string]any, h Highlight) error {
+	err := t.AddEntry(row)
+	if t.rowHighlights == nil {
+		t.rowHighlights = make(map[int]Highlight)
+	}
+	t.rowHighlights[t.numEntries] = h
+	return err
+}
+
+/**
+ * Highlights every entry in a column
+ *
+ * @param name Header name of the column
+ * @param h Highlight for the column
+ *
+ * @return An error if no column exists with the header name
+ */
+func (t *Table) SetHighlight(name string, h Highlight) error {
+	col, has := t.columns[name]
+	if !has {
+		return fmt.Errorf("no column with header name exists: '%s'", name)
+	}
+	col.highlight = h
+	return nil
+}
+
+/**
+ * Gets the highlight of an entry
+ *
+ * @param row Row number of the entry (1 based)
+ * @param col Column the entry is located in
+ *
+ * @return The combined highlight of the column, row and cell
+ */
+func (t *Table) cellHighlight(row int, col *column) Highlight {
+	h := col.highlight.merge(t.rowHighlights[row])
+	if cell, isCell := col.entries[row].(Cell); isCell {
+		h = h.merge(cell.Highlight)
+	}
+	return h
+}
+
+/**
+ * Combines two highlights
+ *
+ * @param other Highlight whose set attributes take priority
+ *
+ * @return The combined highlight
+ */
+func (h Highlight) merge(other Highlight) Highlight {
+	if other.Foreground != DEFAULT_COLOR {
+		h.Foreground = other.Foreground
+	}
+	if other.Background != DEFAULT_COLOR {
+		h.Background = other.Background
+	}
+	h.Bold = h.Bold || other.Bold
+	h.Dim = h.Dim || other.Dim
+	return h
+}
+
+/**
+ * Wraps a value with the ANSI codes of the highlight
+ *
+ * @param val Value to wrap
+ *
+ * @return The value with ANSI codes, or as is if there is nothing to apply
+ */
+func (h Highlight) apply(val string) string {
+	if h == (Highlight{}) {
+		return val
+	}
+
+	codes := make([]string, 0, 4)
+	if h.Bold {
+		codes = append(codes, "1")
+	}
+	if h.Dim {
+		codes = append(codes, "2")
+	}
+	if h.Foreground != DEFAULT_COLOR {
+		codes = append(codes, fmt.Sprint(29+int(h.Foreground)))
+	}
+	if h.Background != DEFAULT_COLOR {
+		codes = append(codes, fmt.Sprint(39+int(h.Background)))
+	}
+
+	return "\x1b[" + strings.Join(codes, ";") + "m" + val + "\x1b[0m"
+}
+
+/**
+ * Removes all ANSI codes from a value
+ *
+ * @param val Value that might have ANSI codes
+ *
+ * @return The value without any ANSI codes
+ */
+func stripAnsi(val string) string {
+	return ansiEscape.ReplaceAllString(val, "")
+}
+
+/**
+ * Determines if colors should be printed to a writer
+ *
+ * @param w Writer the table is printed to
+ *
+ * @return False if NO_COLOR is set or the writer isn't a terminal, otherwise true
+ */
+func ColorsFor(w io.Writer) bool {
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+
+	file, isFile := w.(*os.File)
+	if !isFile {
+		return false
+	}
+	info, err := file.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+/**
+ * Determines if the table is printed with colors
+ *
+ * @param w Writer the table is printed to, nil if rendered to a string
+ *
+ * @return True if the highlights should be printed
+ */
+func (t *Table) colors(w io.Writer) bool {
+	switch {
+	case t.Colors == ALWAYS_COLORS:
+		return true
+	case t.Colors == NEVER_COLORS:
+		return false
+	case w == nil:
+		return ColorsEnabled && os.Getenv("NO_COLOR") == ""
+	}
+	return ColorsFor(w)
+}
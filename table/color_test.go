@@ -0,0 +1,107 @@
+package table
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestHighlightPrecedence(t *testing.T) {
+	tbl := new(Table)
+	tbl.CreateColumn("Name", LEFT, 0)
+	tbl.SetHighlight("Name", Highlight{Foreground: RED, Background: BLUE, Dim: true})
+	tbl.AddHighlightedEntry(map[string]any{"Name": "row"}, Highlight{Foreground: GREEN, Bold: true})
+	tbl.AddHighlightedEntry(map[string]any{"Name": Highlighted("cell", Highlight{Foreground: YELLOW, Background: CYAN})},
+		Highlight{Foreground: GREEN})
+	tbl.AddEntry(map[string]any{"Name": "column"})
+
+	for row, want := range map[int]Highlight{
+		1: {Foreground: GREEN, Background: BLUE, Bold: true, Dim: true},
+		2: {Foreground: YELLOW, Background: CYAN, Dim: true},
+		3: {Foreground: RED, Background: BLUE, Dim: true},
+	} {
+		if got := tbl.cellHighlight(row, tbl.columns["Name"]); got != want {
+			t.Errorf("row %d: got %+v, expected %+v", row, got, want)
+		}
+	}
+}
+
+func TestAnsiCodesHaveNoWidth(t *testing.T) {
+	tbl := new(Table)
+	tbl.Colors = ALWAYS_COLORS
+	tbl.CreateColumn("Name", LEFT, 0)
+	tbl.CreateColumn("Points", RIGHT, 0)
+	tbl.AddEntry(map[string]any{"Name": Highlighted("Ann", Highlight{Foreground: RED, Bold: true}), "Points": 5})
+	tbl.AddEntry(map[string]any{"Name": "Bob", "Points": 10})
+
+	out := tbl.String()
+	if !strings.Contains(out, "\x1b[1;31m Ann  \x1b[0m") {
+		t.Errorf("expected the highlighted cell to be padded inside its codes: %q", out)
+	}
+
+	// Once the codes are removed every line is as wide as without colors
+	tbl.Colors = NEVER_COLORS
+	if got, want := stripAnsi(out), tbl.String(); got != want {
+		t.Errorf("got:\n%s\nexpected:\n%s", got, want)
+	}
+	if width := tbl.columns["Name"].width(tbl); width != 4 {
+		t.Errorf("expected the Name column to be 4 wide, got %d", width)
+	}
+}
+
+func TestColorModes(t *testing.T) {
+	colors := ColorsEnabled
+	defer func() { ColorsEnabled = colors }()
+	t.Setenv("NO_COLOR", "")
+
+	tbl := new(Table)
+	tbl.CreateColumn("Name", LEFT, 0)
+	tbl.SetHighlight("Name", Highlight{Bold: true})
+	tbl.AddEntry(map[string]any{"Name": "Ann"})
+
+	hasCodes := func(out string) bool { return strings.Contains(out, "\x1b[") }
+
+	ColorsEnabled = true
+	if !hasCodes(tbl.String()) {
+		t.Error("expected colors when rendering to a string with colors enabled")
+	}
+
+	// Writers that aren't terminals have no colors
+	var buf bytes.Buffer
+	tbl.WriteTo(&buf)
+	if hasCodes(buf.String()) {
+		t.Error("expected no colors when writing to a buffer")
+	}
+	file, err := os.Create(t.TempDir() + "/table.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	if ColorsFor(file) {
+		t.Error("expected no colors for a regular file")
+	}
+
+	// An explicit mode overrides the writer
+	tbl.Colors = ALWAYS_COLORS
+	buf.Reset()
+	tbl.WriteTo(&buf)
+	if !hasCodes(buf.String()) {
+		t.Error("expected colors when always enabled")
+	}
+	tbl.Colors = NEVER_COLORS
+	if hasCodes(tbl.String()) {
+		t.Error("expected no colors when never enabled")
+	}
+
+	// NO_COLOR is checked when rendering, not only at startup
+	tbl.Colors = AUTO_COLORS
+	t.Setenv("NO_COLOR", "1")
+	if hasCodes(tbl.String()) {
+		t.Error("expected NO_COLOR to disable colors")
+	}
+	tbl.Colors = ALWAYS_COLORS
+	if !hasCodes(tbl.String()) {
+		t.Error("expected colors when always enabled even with NO_COLOR")
+	}
+}
@@ -0,0 +1,458 @@
+package table
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"strings"
+)
+
+type Renderer interface {
+	/**
+	 * Renders the table into a specific output format
+	 *
+	 * @param t Table to render
+	 *
+	 * @return The rendered table
+	 */
+	Render(t *Table) string
+}
+
+var (
+	TEXT     Renderer = textRenderer{}
+	MARKDOWN Renderer = markdownRenderer{}
+	CSV      Renderer = csvRenderer{}
+	HTML     Renderer = htmlRenderer{}
+	JSON     Renderer = jsonRenderer{}
+)
+
+/**
+ * Gets a renderer by its format name
+ *
+ * @param name Format name such as "text", "markdown", "md", "csv", "html" or "json"
+ *
+ * @return The renderer for the format, or an error if the format is unknown
+ */
+func RendererFor(name string) (Renderer, error) {
+	switch strings.ToLower(name) {
+	case "", "text", "txt":
+		return TEXT, nil
+	case "markdown", "md":
+		return MARKDOWN, nil
+	case "csv":
+		return CSV, nil
+	case "html":
+		return HTML, nil
+	case "json":
+		return JSON, nil
+	}
+	return nil, fmt.Errorf("unknown table format: '%s'", name)
+}
+
+/**
+ * Renders the table with the given renderer
+ *
+ * @param r Renderer to use. If nil the TEXT renderer is used.
+ *
+ * @return The rendered table
+ */
+func (t *Table) Render(r Renderer) string {
+	if r == nil {
+		r = TEXT
+	}
+	return r.Render(t)
+}
+
+/**
+ * Box drawing text format used when printing to the terminal
+ */
+type textRenderer struct{}
+
+func (textRenderer) Render(t *Table) string {
+	buf := bytes.NewBufferString("")
+	t.writeText(buf, t.colors(nil))
+	return buf.String()
+}
+
+/**
+ * Writes the lines of the TEXT format for a table with known column widths
+ */
+type textWriter struct {
+	w      io.Writer
+	t      *Table
+	style  Style
+	widths []int
+	aligns []Alignment
+	colors bool
+	n      int64
+	err    error
+}
+
+/**
+ * Creates a writer of the TEXT format
+ *
+ * @param w Where the lines are written
+ * @param t Table with the columns, style and title to write
+ * @param widths Width of each column in column order
+ * @param colors True to print the highlights
+ *
+ * @return The created writer
+ */
+func newTextWriter(w io.Writer, t *Table, widths []int, colors bool) *textWriter {
+	// Widen the last column if the title doesn't fit
+	if len(widths) > 0 {
+		total := len(widths) - 1
+		for _, width := range widths {
+			total += width + (PADDING * 2)
+		}
+		if titleLen := textWidth(t.Title) + (PADDING * 2); total < titleLen {
+			widths[len(widths)-1] += titleLen - total
+		}
+	}
+
+	aligns := make([]Alignment, len(t.columnOrder))
+	for pos, colName := range t.columnOrder {
+		aligns[pos] = t.columns[colName].align()
+	}
+
+	return &textWriter{w: w, t: t, style: t.style(), widths: widths, aligns: aligns, colors: colors}
+}
+
+/**
+ * Writes a string keeping track of the bytes written and the first error
+ *
+ * @param val Value to write
+ */
+func (tw *textWriter) write(val string) {
+	if tw.err != nil {
+		return
+	}
+	n, err := io.WriteString(tw.w, val)
+	tw.n += int64(n)
+	tw.err = err
+}
+
+/**
+ * Writes the top border, title and headers
+ */
+func (tw *textWriter) top() {
+	style := tw.style
+
+	if tw.t.Title != "" {
+		total := len(tw.widths) - 1
+		for _, width := range tw.widths {
+			total += width + (PADDING * 2)
+		}
+
+		if style.Outer && style.Horizontal != 0 {
+			tw.write(string(style.TopLeft) + strings.Repeat(string(style.Horizontal), total) + string(style.TopRight) + "\n\r")
+		}
+		tw.side()
+		tw.write(formatColEntry(tw.t.Title, CENTER, total))
+		tw.side()
+		tw.write("\n\r")
+		tw.divider(style.MidLeft, style.TopMid, style.MidRight)
+	} else if style.Outer {
+		tw.divider(style.TopLeft, style.TopMid, style.TopRight)
+	}
+
+	tw.row(func(col *column) string { return col.header },
+		func(*column) Highlight { return tw.t.HeaderHighlight })
+	tw.separator()
+}
+
+/**
+ * Writes the divider between rows
+ */
+func (tw *textWriter) separator() {
+	tw.divider(tw.style.MidLeft, tw.style.MidMid, tw.style.MidRight)
+}
+
+/**
+ * Writes the bottom border
+ */
+func (tw *textWriter) bottom() {
+	if tw.style.Outer {
+		tw.divider(tw.style.BottomLeft, tw.style.BottomMid, tw.style.BottomRight)
+	}
+}
+
+/**
+ * Writes the side border if the style has one
+ */
+func (tw *textWriter) side() {
+	if tw.style.Outer {
+		tw.write(string(tw.style.Vertical))
+	}
+}
+
+/**
+ * Writes a horizontal divider using the provided corner and joint characters
+ */
+func (tw *textWriter) divider(left, mid, right rune) {
+	if tw.style.Horizontal == 0 {
+		return
+	}
+
+	line := bytes.NewBufferString("")
+	if tw.style.Outer {
+		line.WriteRune(left)
+	}
+	for pos, width := range tw.widths {
+		line.WriteString(strings.Repeat(string(tw.style.Horizontal), width+(PADDING*2)))
+		if pos+1 != len(tw.widths) {
+			line.WriteRune(mid)
+		}
+	}
+	if tw.style.Outer {
+		line.WriteRune(right)
+	}
+	line.WriteString("\n\r")
+
+	tw.write(line.String())
+}
+
+/**
+ * Writes a row of values between vertical dividers,
+ * values too wide for their column span multiple lines
+ *
+ * @param val Gets the value to write for a column
+ * @param highlight Gets the highlight of the value for a column
+ */
+func (tw *textWriter) row(val func(col *column) string, highlight func(col *column) Highlight) {
+	cols := tw.t.columnOrder
+	cells := make([][]string, len(cols))
+	height := 1
+	for pos, colName := range cols {
+		cells[pos] = tw.t.columns[colName].lines(val(tw.t.columns[colName]), tw.widths[pos])
+		height = max(height, len(cells[pos]))
+	}
+
+	line := bytes.NewBufferString("")
+	for i := 0; i < height; i++ {
+		if tw.style.Outer {
+			line.WriteRune(tw.style.Vertical)
+		}
+		for pos, colName := range cols {
+			var cell string
+			if i < len(cells[pos]) {
+				cell = cells[pos][i]
+			}
+			cell = pad(formatColEntry(cell, tw.aligns[pos], tw.widths[pos]), PADDING)
+			if tw.colors {
+				cell = highlight(tw.t.columns[colName]).apply(cell)
+			}
+			line.WriteString(cell)
+			if pos+1 != len(cols) {
+				line.WriteRune(tw.style.Vertical)
+			}
+		}
+		if tw.style.Outer {
+			line.WriteRune(tw.style.Vertical)
+		}
+		line.WriteString("\n\r")
+	}
+
+	tw.write(line.String())
+}
+
+/**
+ * GitHub flavored Markdown table
+ */
+type markdownRenderer struct{}
+
+func (markdownRenderer) Render(t *Table) string {
+	buf := bytes.NewBufferString("")
+	escape := strings.NewReplacer("|", "\\|", "\n", " ", "\r", "")
+
+	if t.Title != "" {
+		fmt.Fprintf(buf, "**%s**\n\n", escape.Replace(t.Title))
+	}
+
+	buf.WriteString("|")
+	for _, colName := range t.columnOrder {
+		fmt.Fprintf(buf, " %s |", escape.Replace(t.columns[colName].header))
+	}
+	buf.WriteString("\n|")
+	for _, colName := range t.columnOrder {
+		switch t.columns[colName].align() {
+		case CENTER:
+			buf.WriteString(":---:|")
+		case RIGHT:
+			buf.WriteString("---:|")
+		default:
+			buf.WriteString(":---|")
+		}
+	}
+	buf.WriteString("\n")
+
+	for i := 1; i <= t.numEntries; i++ {
+		buf.WriteString("|")
+		for _, colName := range t.columnOrder {
+			fmt.Fprintf(buf, " %s |", escape.Replace(t.cell(i, t.columns[colName])))
+		}
+		buf.WriteString("\n")
+	}
+
+	// Markdown has no footer section so footers are bolded rows
+	for i := 1; i <= t.numFooters; i++ {
+		buf.WriteString("|")
+		for _, colName := range t.columnOrder {
+			if val := t.footer(i, t.columns[colName]); val != "" {
+				fmt.Fprintf(buf, " **%s** |", escape.Replace(val))
+			} else {
+				buf.WriteString("  |")
+			}
+		}
+		buf.WriteString("\n")
+	}
+
+	return buf.String()
+}
+
+/**
+ * Comma separated values with a header record
+ */
+type csvRenderer struct{}
+
+func (csvRenderer) Render(t *Table) string {
+	buf := bytes.NewBufferString("")
+	w := csv.NewWriter(buf)
+
+	w.Write(t.columnOrder)
+	for i := 1; i <= t.numEntries; i++ {
+		record := make([]string, 0, len(t.columnOrder))
+		for _, colName := range t.columnOrder {
+			record = append(record, t.cell(i, t.columns[colName]))
+		}
+		w.Write(record)
+	}
+	for i := 1; i <= t.numFooters; i++ {
+		record := make([]string, 0, len(t.columnOrder))
+		for _, colName := range t.columnOrder {
+			record = append(record, t.footer(i, t.columns[colName]))
+		}
+		w.Write(record)
+	}
+	w.Flush()
+
+	return buf.String()
+}
+
+/**
+ * HTML <table> element
+ */
+type htmlRenderer struct{}
+
+func (htmlRenderer) Render(t *Table) string {
+	buf := bytes.NewBufferString("")
+	align := map[Alignment]string{LEFT: "left", CENTER: "center", RIGHT: "right"}
+
+	buf.WriteString("<table>\n")
+	if t.Title != "" {
+		fmt.Fprintf(buf, "  <caption>%s</caption>\n", html.EscapeString(t.Title))
+	}
+	buf.WriteString("  <thead>\n    <tr>")
+	for _, colName := range t.columnOrder {
+		col := t.columns[colName]
+		fmt.Fprintf(buf, "<th align=\"%s\">%s</th>", align[col.align()], html.EscapeString(col.header))
+	}
+	buf.WriteString("</tr>\n  </thead>\n  <tbody>\n")
+
+	for i := 1; i <= t.numEntries; i++ {
+		buf.WriteString("    <tr>")
+		for _, colName := range t.columnOrder {
+			col := t.columns[colName]
+			fmt.Fprintf(buf, "<td align=\"%s\">%s</td>", align[col.align()], html.EscapeString(t.cell(i, col)))
+		}
+		buf.WriteString("</tr>\n")
+	}
+	buf.WriteString("  </tbody>\n")
+
+	if t.numFooters > 0 {
+		buf.WriteString("  <tfoot>\n")
+		for i := 1; i <= t.numFooters; i++ {
+			buf.WriteString("    <tr>")
+			for _, colName := range t.columnOrder {
+				col := t.columns[colName]
+				fmt.Fprintf(buf, "<td align=\"%s\">%s</td>", align[col.align()], html.EscapeString(t.footer(i, col)))
+			}
+			buf.WriteString("</tr>\n")
+		}
+		buf.WriteString("  </tfoot>\n")
+	}
+	buf.WriteString("</table>\n")
+
+	return buf.String()
+}
+
+/**
+ * JSON array of row objects keyed by header name in column order, with the
+ * footers as the last rows. Numbers keep their type unless the column formats them.
+ */
+type jsonRenderer struct{}
+
+func (jsonRenderer) Render(t *Table) string {
+	buf := bytes.NewBufferString("[")
+
+	// Writes a row object, val gets the raw value and the printed text of a column
+	row := func(first bool, val func(col *column) (any, bool, string)) {
+		if !first {
+			buf.WriteString(",")
+		}
+		buf.WriteString("\n  {")
+		for pos, colName := range t.columnOrder {
+			if pos > 0 {
+				buf.WriteString(", ")
+			}
+			col := t.columns[colName]
+			key, _ := json.Marshal(colName)
+			fmt.Fprintf(buf, "%s: %s", key, col.jsonValue(val(col)))
+		}
+		buf.WriteString("}")
+	}
+
+	for i := 1; i <= t.numEntries; i++ {
+		row(i == 1, func(col *column) (any, bool, string) {
+			raw, has := col.entries[i]
+			return raw, has, t.cell(i, col)
+		})
+	}
+	for i := 1; i <= t.numFooters; i++ {
+		row(i == 1 && t.numEntries == 0, func(col *column) (any, bool, string) {
+			raw, has := col.footers[i]
+			return raw, has, t.footer(i, col)
+		})
+	}
+	if t.numEntries+t.numFooters > 0 {
+		buf.WriteString("\n")
+	}
+	buf.WriteString("]\n")
+
+	return buf.String()
+}
+
+/**
+ * Encodes a value of the column as JSON
+ *
+ * @param raw Value added to the table
+ * @param has False if the value is missing
+ * @param text Value as it's printed
+ *
+ * @return The number if the value is one and the column has no formatter, otherwise the printed text
+ */
+func (col *column) jsonValue(raw any, has bool, text string) []byte {
+	if _, isNum := number(raw); has && isNum && col.formatter == nil {
+		if cell, isCell := raw.(Cell); isCell {
+			raw = cell.Value
+		}
+		if data, err := json.Marshal(raw); err == nil {
+			return data
+		}
+	}
+	data, _ := json.Marshal(text)
+	return data
+}
@@ -0,0 +1,137 @@
+package table
+
+import (
+	"fmt"
+	"testing"
+)
+
+/**
+ * Creates a table whose values need escaping in every format, with a missing cell
+ *
+ * @return The table
+ */
+func escapingTable() *Table {
+	t := new(Table)
+	t.CreateColumn("Name", LEFT, 0)
+	t.CreateColumn("Note", CENTER, 0)
+	t.CreateColumn("Score", RIGHT, '-')
+
+	t.AddEntry(map[string]any{"Name": "a|b", "Note": "x,y", "Score": 10})
+	t.AddEntry(map[string]any{"Name": `say "hi"`, "Note": "<&>"})
+	return t
+}
+
+func TestRendererFor(t *testing.T) {
+	for name, want := range map[string]Renderer{"": TEXT, "md": MARKDOWN, "CSV": CSV, "html": HTML, "json": JSON} {
+		if got, err := RendererFor(name); err != nil || got != want {
+			t.Errorf("%q: got %v, %v", name, got, err)
+		}
+	}
+	if _, err := RendererFor("xml"); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
+
+func TestRenderMarkdown(t *testing.T) {
+	tbl := escapingTable()
+	tbl.Title = "A|B"
+	tbl.AddFooter(map[string]any{"Name": "Total", "Score": 10})
+
+	want := "**A\\|B**\n\n" +
+		"| Name | Note | Score |\n" +
+		"|:---|:---:|---:|\n" +
+		"| a\\|b | x,y | 10 |\n" +
+		"| say \"hi\" | <&> | - |\n" +
+		"| **Total** |  | **10** |\n"
+	if got := tbl.Render(MARKDOWN); got != want {
+		t.Errorf("got:\n%s\nexpected:\n%s", got, want)
+	}
+}
+
+func TestRenderCSV(t *testing.T) {
+	tbl := escapingTable()
+	tbl.AddFooter(map[string]any{"Name": "Total", "Score": 10})
+
+	want := "Name,Note,Score\n" +
+		"a|b,\"x,y\",10\n" +
+		"\"say \"\"hi\"\"\",<&>,-\n" +
+		"Total,,10\n"
+	if got := tbl.Render(CSV); got != want {
+		t.Errorf("got:\n%s\nexpected:\n%s", got, want)
+	}
+}
+
+func TestRenderHTML(t *testing.T) {
+	tbl := escapingTable()
+	tbl.Title = "<Scores>"
+	tbl.AddFooter(map[string]any{"Name": "Total", "Score": 10})
+
+	want := "<table>\n" +
+		"  <caption>&lt;Scores&gt;</caption>\n" +
+		"  <thead>\n" +
+		"    <tr><th align=\"left\">Name</th><th align=\"center\">Note</th><th align=\"right\">Score</th></tr>\n" +
+		"  </thead>\n" +
+		"  <tbody>\n" +
+		"    <tr><td align=\"left\">a|b</td><td align=\"center\">x,y</td><td align=\"right\">10</td></tr>\n" +
+		"    <tr><td align=\"left\">say &#34;hi&#34;</td><td align=\"center\">&lt;&amp;&gt;</td><td align=\"right\">-</td></tr>\n" +
+		"  </tbody>\n" +
+		"  <tfoot>\n" +
+		"    <tr><td align=\"left\">Total</td><td align=\"center\"></td><td align=\"right\">10</td></tr>\n" +
+		"  </tfoot>\n" +
+		"</table>\n"
+	if got := tbl.Render(HTML); got != want {
+		t.Errorf("got:\n%s\nexpected:\n%s", got, want)
+	}
+}
+
+func TestRenderJSON(t *testing.T) {
+	tbl := escapingTable()
+	tbl.AddFooter(map[string]any{"Name": "Total", "Score": 10})
+
+	want := "[\n" +
+		"  {\"Name\": \"a|b\", \"Note\": \"x,y\", \"Score\": 10},\n" +
+		"  {\"Name\": \"say \\\"hi\\\"\", \"Note\": \"\\u003c\\u0026\\u003e\", \"Score\": \"-\"},\n" +
+		"  {\"Name\": \"Total\", \"Note\": \"\", \"Score\": 10}\n" +
+		"]\n"
+	if got := tbl.Render(JSON); got != want {
+		t.Errorf("got:\n%s\nexpected:\n%s", got, want)
+	}
+
+	if got := new(Table).Render(JSON); got != "[]\n" {
+		t.Errorf("expected an empty array, got %q", got)
+	}
+}
+
+func TestRenderJSONNumbers(t *testing.T) {
+	tbl := new(Table)
+	tbl.CreateColumn("Avg", RIGHT, 0)
+	tbl.CreateColumn("Pct", RIGHT, 0)
+	tbl.SetFormatter("Pct", func(val any) string { return fmt.Sprintf("%.0f%%", val) })
+	tbl.AddEntry(map[string]any{"Avg": Highlighted(97.5, Highlight{Bold: true}), "Pct": 50.0})
+
+	want := "[\n  {\"Avg\": 97.5, \"Pct\": \"50%\"}\n]\n"
+	if got := tbl.Render(JSON); got != want {
+		t.Errorf("got:\n%s\nexpected:\n%s", got, want)
+	}
+
+	// A table of only footers is still an array of rows
+	footers := new(Table)
+	footers.CreateColumn("Total", RIGHT, 0)
+	footers.AddFooter(map[string]any{"Total": uint(195)})
+	if got := footers.Render(JSON); got != "[\n  {\"Total\": 195}\n]\n" {
+		t.Errorf("unexpected footers: %q", got)
+	}
+}
+
+func TestRenderAutoAlignment(t *testing.T) {
+	tbl := new(Table)
+	tbl.CreateColumn("Num", AUTO, 0)
+	tbl.CreateColumn("Mixed", AUTO, 0)
+	tbl.AddEntry(map[string]any{"Num": 1, "Mixed": 2})
+	tbl.AddEntry(map[string]any{"Num": 2.5, "Mixed": "two"})
+
+	want := "| Num | Mixed |\n|---:|:---|\n| 1 | 2 |\n| 2.5 | two |\n"
+	if got := tbl.Render(MARKDOWN); got != want {
+		t.Errorf("got:\n%s\nexpected:\n%s", got, want)
+	}
+}
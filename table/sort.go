@@ -0,0 +1,174 @@
+package table
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type SortKey struct {
+	Column     string // Header name of the column to sort by
+	Descending bool   // True to sort largest to smallest
+}
+
+/**
+ * Sorts the entries of the table by one or more columns.
+ *
+ * @note Numbers are compared by value, everything else is compared by its
+ *       formatted string. Entries that are equal keep their current order.
+ *       Footers are never sorted.
+ *
+ * @param keys Columns to sort by in order of priority
+ *
+ * @return An error if one or more keys refer to unspecified columns
+ */
+func (t *Table) SortBy(keys ...SortKey) error {
+	for _, key := range keys {
+		if _, has := t.columns[key.Column]; !has {
+			return fmt.Errorf("no column with header name exists: '%s'", key.Column)
+		}
+	}
+
+	order := make([]int, t.numEntries)
+	for i := range order {
+		order[i] = i + 1
+	}
+
+	sort.SliceStable(order, func(i, j int) bool {
+		for _, key := range keys {
+			cmp := t.compare(t.columns[key.Column], order[i], order[j])
+			if key.Descending {
+				cmp = -cmp
+			}
+			if cmp != 0 {
+				return cmp < 0
+			}
+		}
+		return false
+	})
+
+	for _, col := range t.columns {
+		entries := make(map[int]any, len(col.entries))
+		for newRow, oldRow := range order {
+			if val, has := col.entries[oldRow]; has {
+				entries[newRow+1] = val
+			}
+		}
+		col.entries = entries
+	}
+
+	if t.rowHighlights != nil {
+		highlights := make(map[int]Highlight, len(t.rowHighlights))
+		for newRow, oldRow := range order {
+			if h, has := t.rowHighlights[oldRow]; has {
+				highlights[newRow+1] = h
+			}
+		}
+		t.rowHighlights = highlights
+	}
+
+	return nil
+}
+
+/**
+ * Compares the values of two entries in a column
+ *
+ * @param col Column to compare the values in
+ * @param a Row number of the first entry
+ * @param b Row number of the second entry
+ *
+ * @return -1 if a is less than b, 1 if a is greater than b, otherwise 0
+ */
+func (t *Table) compare(col *column, a, b int) int {
+	aVal, aHas := col.entries[a]
+	bVal, bHas := col.entries[b]
+
+	// Missing values are always smaller
+	switch {
+	case !aHas && !bHas:
+		return 0
+	case !aHas:
+		return -1
+	case !bHas:
+		return 1
+	}
+
+	aNum, aIsNum := number(aVal)
+	bNum, bIsNum := number(bVal)
+	if !aIsNum {
+		aNum, aIsNum = parseNumber(col.format(aVal))
+	}
+	if !bIsNum {
+		bNum, bIsNum = parseNumber(col.format(bVal))
+	}
+
+	switch {
+	case aIsNum && bIsNum:
+		if aNum < bNum {
+			return -1
+		} else if aNum > bNum {
+			return 1
+		}
+		return 0
+	case aIsNum:
+		return -1 // Numbers before text
+	case bIsNum:
+		return 1
+	}
+
+	return strings.Compare(col.format(aVal), col.format(bVal))
+}
+
+/**
+ * Converts a typed value to a number
+ *
+ * @param val Value to convert
+ *
+ * @return The value as a float and true if it's a number type, otherwise false
+ */
+func number(val any) (float64, bool) {
+	if cell, isCell := val.(Cell); isCell {
+		val = cell.Value
+	}
+
+	switch v := val.(type) {
+	case int:
+		return float64(v), true
+	case int8:
+		return float64(v), true
+	case int16:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint:
+		return float64(v), true
+	case uint8:
+		return float64(v), true
+	case uint16:
+		return float64(v), true
+	case uint32:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case float32:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+	return 0, false
+}
+
+/**
+ * Parses a formatted value as a number
+ *
+ * @param val Value to parse such as "1,250" or "-3.5"
+ *
+ * @return The parsed number and true if it was a number, otherwise false
+ */
+func parseNumber(val string) (float64, bool) {
+	num, err := strconv.ParseFloat(strings.ReplaceAll(strings.TrimSpace(val), ",", ""), 64)
+	return num, err == nil
+}
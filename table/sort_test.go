@@ -0,0 +1,74 @@
+package table
+
+import (
+	"slices"
+	"testing"
+)
+
+/**
+ * Gets the printed values of a column from top to bottom
+ *
+ * @param t Table to read
+ * @param name Header name of the column
+ *
+ * @return The values of every entry
+ */
+func columnValues(t *Table, name string) []string {
+	vals := make([]string, t.numEntries)
+	for i := range vals {
+		vals[i] = t.cell(i+1, t.columns[name])
+	}
+	return vals
+}
+
+func TestSortByMultipleKeys(t *testing.T) {
+	tbl := new(Table)
+	tbl.CreateColumn("Team", LEFT, 0)
+	tbl.CreateColumn("Points", RIGHT, 0)
+	tbl.CreateColumn("Name", LEFT, 0)
+	tbl.AddEntry(map[string]any{"Team": "red", "Points": 9, "Name": "Ann"})
+	tbl.AddEntry(map[string]any{"Team": "blue", "Points": "1,250", "Name": "Bob"})
+	tbl.AddEntry(map[string]any{"Team": "red", "Points": 100, "Name": "Cy"})
+	tbl.AddEntry(map[string]any{"Team": "blue", "Points": "n/a", "Name": "Di"})
+	tbl.AddEntry(map[string]any{"Team": "red", "Points": 9, "Name": "Ed"})
+	tbl.AddEntry(map[string]any{"Team": "blue", "Name": "Flo"})
+	tbl.AddFooter(map[string]any{"Team": "Total", "Points": 1368})
+
+	if err := tbl.SortBy(SortKey{Column: "Team"}, SortKey{Column: "Points", Descending: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Text sorts after numbers, missing values before everything, and ties keep their order
+	want := []string{"Di", "Bob", "Flo", "Cy", "Ann", "Ed"}
+	if got := columnValues(tbl, "Name"); !slices.Equal(got, want) {
+		t.Errorf("got %v, expected %v", got, want)
+	}
+	if got := tbl.footer(1, tbl.columns["Team"]); got != "Total" {
+		t.Errorf("expected the footer to stay put, got %q", got)
+	}
+}
+
+func TestSortByNumbersAsText(t *testing.T) {
+	tbl := new(Table)
+	tbl.CreateColumn("Points", RIGHT, 0)
+	for _, points := range []any{"10", 9, "-3.5", 100.25, "2"} {
+		tbl.AddEntry(map[string]any{"Points": points})
+	}
+
+	if err := tbl.SortBy(SortKey{Column: "Points"}); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"-3.5", "2", "9", "10", "100.25"}
+	if got := columnValues(tbl, "Points"); !slices.Equal(got, want) {
+		t.Errorf("got %v, expected %v", got, want)
+	}
+}
+
+func TestSortByUnknownColumn(t *testing.T) {
+	tbl := new(Table)
+	tbl.CreateColumn("Points", RIGHT, 0)
+	if err := tbl.SortBy(SortKey{Column: "Points"}, SortKey{Column: "Name"}); err == nil {
+		t.Error("expected an error for an unknown column")
+	}
+}
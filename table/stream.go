@@ -0,0 +1,263 @@
+package table
+
+import (
+	"fmt"
+	"io"
+	"sort"
+)
+
+/**
+ * Writes rows of a table in the TEXT format as they are added, instead of
+ * holding every row in memory until the table is printed.
+ *
+ * @note Column widths can't grow after the first line is written, so they are
+ *       declared up front. Values wider than their column are handled with the
+ *       column's overflow, TRUNCATE unless SetMaxWidth was called.
+ */
+type StreamWriter struct {
+	tw      *textWriter
+	started bool
+	rows    int
+	footers int
+	closed  bool
+}
+
+/**
+ * Creates a streaming writer for a table.
+ *
+ * @note The table is only used for its columns, style, title, highlights,
+ *       formatters and row separators. Entries already added to it are not written.
+ *
+ * @param w Where the table is written
+ * @param t Table defining the columns
+ * @param widths Width per column by header name. Columns not provided use their
+ *               max width if set, otherwise the width of their header.
+ *
+ * @return The streaming writer, or an error if a width refers to an unspecified column or is negative
+ */
+func NewStreamWriter(w io.Writer, t *Table, widths map[string]int) (*StreamWriter, error) {
+	colWidths := make([]int, len(t.columnOrder))
+	for pos, colName := range t.columnOrder {
+		col := t.columns[colName]
+		colWidths[pos] = max(col.maxWidth, textWidth(col.header))
+	}
+
+	for name, width := range widths {
+		if _, has := t.columns[name]; !has {
+			return nil, fmt.Errorf("no column with header name exists: '%s'", name)
+		} else if width < 0 {
+			return nil, fmt.Errorf("invalid width for column '%s': %d", name, width)
+		}
+		for pos, colName := range t.columnOrder {
+			if colName == name {
+				colWidths[pos] = width
+			}
+		}
+	}
+
+	return &StreamWriter{tw: newTextWriter(w, t, colWidths, t.colors(w))}, nil
+}
+
+/**
+ * Writes the top border, title and headers if they haven't been written yet
+ *
+ * @return Any error from the underlying writer
+ */
+func (s *StreamWriter) WriteHeader() error {
+	if s.closed {
+		return fmt.Errorf("stream writer is closed")
+	}
+	if !s.started {
+		s.started = true
+		s.tw.top()
+	}
+	return s.tw.err
+}
+
+/**
+ * Writes an entry to the table.
+ *
+ * @param row Contents of the row. Keys = Header Name, Values = Column Value
+ *
+ * @return An error if one or more Keys refer to unspecified columns, entries
+ *         are written after footers, or the underlying writer fails
+ */
+func (s *StreamWriter) WriteRow(row map[string]any) error {
+	if s.footers > 0 {
+		return fmt.Errorf("can't write entries after footers")
+	} else if err := s.checkColumns(row); err != nil {
+		return err
+	} else if err := s.WriteHeader(); err != nil {
+		return err
+	}
+
+	s.rows++
+	if s.tw.t.RowSeparators && s.rows > 1 {
+		s.tw.separator()
+	}
+	return s.writeRow(row, false)
+}
+
+/**
+ * Writes a footer row to the table such as a totals row.
+ *
+ * @param row Contents of the row. Keys = Header Name, Values = Column Value
+ *
+ * @return An error if one or more Keys refer to unspecified columns or the underlying writer fails
+ */
+func (s *StreamWriter) WriteFooter(row map[string]any) error {
+	if err := s.checkColumns(row); err != nil {
+		return err
+	} else if err := s.WriteHeader(); err != nil {
+		return err
+	}
+
+	s.footers++
+	if s.tw.t.RowSeparators || s.footers == 1 {
+		s.tw.separator()
+	}
+	return s.writeRow(row, true)
+}
+
+/**
+ * Writes the bottom border. Nothing can be written afterwards.
+ *
+ * @note The underlying writer is not closed
+ *
+ * @return Any error from the underlying writer
+ */
+func (s *StreamWriter) Close() error {
+	if s.closed {
+		return nil
+	}
+	if err := s.WriteHeader(); err != nil {
+		return err
+	}
+	s.closed = true
+	s.tw.bottom()
+	return s.tw.err
+}
+
+/**
+ * Gets the number of bytes written thus far
+ *
+ * @return Bytes written to the underlying writer
+ */
+func (s *StreamWriter) Written() int64 {
+	return s.tw.n
+}
+
+/**
+ * Checks that every key of a row refers to a column, before anything is written
+ *
+ * @param row Contents of the row. Keys = Header Name, Values = Column Value
+ *
+ * @return An error if one or more Keys refer to unspecified columns
+ */
+func (s *StreamWriter) checkColumns(row map[string]any) error {
+	var missingHdrs []string
+	for hdr := range row {
+		if _, has := s.tw.t.columns[hdr]; !has {
+			missingHdrs = append(missingHdrs, hdr)
+		}
+	}
+	if 0 < len(missingHdrs) {
+		sort.Strings(missingHdrs)
+		return fmt.Errorf("no column(s) with header name(s) exist: %v", missingHdrs)
+	}
+	return nil
+}
+
+/**
+ * Formats and writes a row
+ *
+ * @param row Contents of the row. Keys = Header Name, Values = Column Value
+ * @param footer True if the row is a footer, so missing values are left empty
+ *
+ * @return Any error from the underlying writer
+ */
+func (s *StreamWriter) writeRow(row map[string]any, footer bool) error {
+	t := s.tw.t
+
+	// AUTO alignment can only be resolved per value when streaming
+	for pos, colName := range t.columnOrder {
+		col := t.columns[colName]
+		if col.alignment == AUTO {
+			if _, isNum := number(row[colName]); isNum {
+				s.tw.aligns[pos] = RIGHT
+			} else {
+				s.tw.aligns[pos] = LEFT
+			}
+		}
+	}
+
+	s.tw.row(func(col *column) string {
+		val, has := row[col.header]
+		switch {
+		case has:
+			return col.format(val)
+		case footer || col.missingVal == EMPTY_MISSING_VAL:
+			return ""
+		}
+		return string(col.missingVal)
+	}, func(col *column) Highlight {
+		h := col.highlight
+		if cell, isCell := row[col.header].(Cell); isCell {
+			h = h.merge(cell.Highlight)
+		}
+		return h
+	})
+
+	return s.tw.err
+}
+
+/**
+ * Writes the table in the TEXT format a row at a time
+ *
+ * @note With AUTO_COLORS the highlights are only printed if the writer is a terminal
+ *
+ * @param w Where the table is written
+ *
+ * @return Number of bytes written and any error from the writer
+ */
+func (t *Table) WriteTo(w io.Writer) (int64, error) {
+	return t.writeText(w, t.colors(w))
+}
+
+/**
+ * Writes the table in the TEXT format a row at a time
+ *
+ * @param w Where the table is written
+ * @param colors True to print the highlights
+ *
+ * @return Number of bytes written and any error from the writer
+ */
+func (t *Table) writeText(w io.Writer, colors bool) (int64, error) {
+	widths := make([]int, len(t.columnOrder))
+	for pos, colName := range t.columnOrder {
+		widths[pos] = t.columns[colName].width(t)
+	}
+
+	tw := newTextWriter(w, t, widths, colors)
+	tw.top()
+
+	for i := 1; i <= t.numEntries; i++ {
+		if t.RowSeparators && i > 1 {
+			tw.separator()
+		}
+		tw.row(func(col *column) string { return t.cell(i, col) },
+			func(col *column) Highlight { return t.cellHighlight(i, col) })
+	}
+
+	for i := 1; i <= t.numFooters; i++ {
+		if t.RowSeparators || i == 1 {
+			tw.separator()
+		}
+		tw.row(func(col *column) string { return t.footer(i, col) },
+			func(col *column) Highlight { return col.highlight })
+	}
+
+	tw.bottom()
+
+	return tw.n, tw.err
+}
@@ -0,0 +1,185 @@
+package table
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+/**
+ * Writer that fails every write
+ */
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestStreamMatchesTable(t *testing.T) {
+	tbl := scoresTable()
+	tbl.Style = ROUNDED_STYLE
+	tbl.Title = "Scores"
+	tbl.Colors = NEVER_COLORS
+	tbl.AddFooter(map[string]any{"Player": "Total", "Points": 195})
+
+	var buf bytes.Buffer
+	s, err := NewStreamWriter(&buf, tbl, map[string]int{"Player": 6})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, row := range []map[string]any{{"Player": "Ann", "Points": 120}, {"Player": "Bob", "Points": 75}} {
+		if err := s.WriteRow(row); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.WriteFooter(map[string]any{"Player": "Total", "Points": 195}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := buf.String(), tbl.String(); got != want {
+		t.Errorf("got:\n%s\nexpected:\n%s", got, want)
+	}
+	if s.Written() != int64(buf.Len()) {
+		t.Errorf("expected %d bytes written, got %d", buf.Len(), s.Written())
+	}
+}
+
+func TestStreamFixedWidths(t *testing.T) {
+	tbl := new(Table)
+	tbl.CreateColumn("Name", LEFT, 0)
+	tbl.CreateColumn("Note", LEFT, '-')
+	tbl.CreateColumn("Points", AUTO, 0)
+	tbl.SetMaxWidth("Note", 6, WRAP)
+
+	var buf bytes.Buffer
+	s, err := NewStreamWriter(&buf, tbl, map[string]int{"Name": 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.WriteRow(map[string]any{"Name": "Alexander", "Note": "banks early", "Points": 5})
+	s.WriteRow(map[string]any{"Name": "Bo", "Points": "n/a"})
+	s.WriteFooter(map[string]any{"Name": "Sum", "Points": 5})
+	s.Close()
+
+	// Name is cut to its fixed width, Note wraps at its max width and Points keeps its header width
+	want := " Nam │ Note   │ Points \n\r" +
+		"─────┼────────┼────────\n\r" +
+		" Ale │ banks  │      5 \n\r" +
+		"     │ early  │        \n\r" +
+		" Bo  │ -      │ n/a    \n\r" +
+		"─────┼────────┼────────\n\r" +
+		" Sum │        │      5 \n\r"
+	if got := buf.String(); got != want {
+		t.Errorf("got:\n%s\nexpected:\n%s", got, want)
+	}
+}
+
+func TestStreamFootersWithRowSeparators(t *testing.T) {
+	tbl := scoresTable()
+	tbl.Style = ASCII_STYLE
+	tbl.RowSeparators = true
+
+	var buf bytes.Buffer
+	s, _ := NewStreamWriter(&buf, tbl, map[string]int{"Player": 7})
+	s.WriteRow(map[string]any{"Player": "Ann", "Points": 120})
+	s.WriteFooter(map[string]any{"Player": "Total", "Points": 120})
+	s.WriteFooter(map[string]any{"Player": "Average", "Points": 120})
+	s.Close()
+
+	want := "+---------+--------+\n\r" +
+		"| Player  | Points |\n\r" +
+		"+---------+--------+\n\r" +
+		"| Ann     |    120 |\n\r" +
+		"+---------+--------+\n\r" +
+		"| Total   |    120 |\n\r" +
+		"+---------+--------+\n\r" +
+		"| Average |    120 |\n\r" +
+		"+---------+--------+\n\r"
+	if got := buf.String(); got != want {
+		t.Errorf("got:\n%s\nexpected:\n%s", got, want)
+	}
+}
+
+func TestStreamInvalidWidths(t *testing.T) {
+	tbl := scoresTable()
+	if _, err := NewStreamWriter(new(bytes.Buffer), tbl, map[string]int{"Points": -1}); err == nil {
+		t.Error("expected an error for a negative width")
+	}
+	if _, err := NewStreamWriter(new(bytes.Buffer), tbl, map[string]int{"Score": 5}); err == nil {
+		t.Error("expected an error for an unknown column")
+	}
+}
+
+func TestStreamUnknownColumn(t *testing.T) {
+	tbl := scoresTable()
+	tbl.Style = ASCII_STYLE
+	tbl.RowSeparators = true
+
+	var buf bytes.Buffer
+	s, _ := NewStreamWriter(&buf, tbl, nil)
+	if err := s.WriteRow(map[string]any{"Player": "Ann", "Score": 1}); err == nil {
+		t.Error("expected an error for an unknown column")
+	}
+	if err := s.WriteFooter(map[string]any{"Score": 1}); err == nil {
+		t.Error("expected an error for an unknown column in a footer")
+	}
+
+	// Nothing was written and the rejected rows don't count, so there's no separator before the first row
+	if buf.Len() != 0 {
+		t.Errorf("expected nothing written, got:\n%s", buf.String())
+	}
+	s.WriteRow(map[string]any{"Player": "Ann", "Points": 1})
+	want := "+--------+--------+\n\r" +
+		"| Player | Points |\n\r" +
+		"+--------+--------+\n\r" +
+		"| Ann    |      1 |\n\r"
+	if got := buf.String(); got != want {
+		t.Errorf("got:\n%s\nexpected:\n%s", got, want)
+	}
+}
+
+func TestStreamWriteAfterFooters(t *testing.T) {
+	s, _ := NewStreamWriter(new(bytes.Buffer), scoresTable(), nil)
+	s.WriteFooter(map[string]any{"Player": "Total"})
+	if err := s.WriteRow(map[string]any{"Player": "Ann"}); err == nil {
+		t.Error("expected an error for an entry after footers")
+	}
+}
+
+func TestStreamClosed(t *testing.T) {
+	var buf bytes.Buffer
+	s, _ := NewStreamWriter(&buf, scoresTable(), nil)
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+	written := buf.Len()
+
+	if err := s.WriteHeader(); err == nil {
+		t.Error("expected an error for a header after closing")
+	}
+	if err := s.WriteRow(map[string]any{"Player": "Ann"}); err == nil {
+		t.Error("expected an error for an entry after closing")
+	}
+	if err := s.WriteFooter(map[string]any{"Player": "Total"}); err == nil {
+		t.Error("expected an error for a footer after closing")
+	}
+	if err := s.Close(); err != nil {
+		t.Errorf("expected closing twice to succeed, got %v", err)
+	}
+	if buf.Len() != written {
+		t.Errorf("expected nothing written after closing, got:\n%s", buf.String())
+	}
+}
+
+func TestStreamWriterError(t *testing.T) {
+	s, _ := NewStreamWriter(failingWriter{}, scoresTable(), nil)
+	if err := s.WriteRow(map[string]any{"Player": "Ann"}); err == nil {
+		t.Error("expected the writer's error")
+	}
+	if err := s.Close(); err == nil {
+		t.Error("expected the writer's error when closing")
+	}
+}
@@ -0,0 +1,137 @@
+package table
+
+import (
+	"fmt"
+	"strings"
+)
+
+/**
+ * Characters used to draw the borders and dividers of a table.
+ *
+ * The naming follows the position of the character in the grid. For example
+ * TopMid is where a vertical divider meets the top border and MidMid is where
+ * a vertical divider crosses a horizontal divider.
+ *
+ * @note A Horizontal value of 0 disables all horizontal lines.
+ */
+type Style struct {
+	Name string
+
+	Horizontal rune
+	Vertical   rune
+
+	TopLeft  rune
+	TopMid   rune
+	TopRight rune
+
+	MidLeft  rune
+	MidMid   rune
+	MidRight rune
+
+	BottomLeft  rune
+	BottomMid   rune
+	BottomRight rune
+
+	// True to draw the top, bottom and side borders,
+	// otherwise only the inner grid is drawn
+	Outer bool
+}
+
+var (
+	NONE_STYLE = Style{
+		Name:     "none",
+		Vertical: ' ',
+	}
+
+	ASCII_STYLE = Style{
+		Name:       "ascii",
+		Horizontal: '-', Vertical: '|',
+		TopLeft: '+', TopMid: '+', TopRight: '+',
+		MidLeft: '+', MidMid: '+', MidRight: '+',
+		BottomLeft: '+', BottomMid: '+', BottomRight: '+',
+		Outer: true,
+	}
+
+	SINGLE_STYLE = Style{
+		Name:       "single",
+		Horizontal: SINGLE_HORIZONTAL_DIV, Vertical: SINGLE_VERTICAL_DIV,
+		TopLeft: '┌', TopMid: '┬', TopRight: '┐',
+		MidLeft: '├', MidMid: SINGLE_CROSS_DIV, MidRight: '┤',
+		BottomLeft: '└', BottomMid: '┴', BottomRight: '┘',
+		Outer: true,
+	}
+
+	DOUBLE_STYLE = Style{
+		Name:       "double",
+		Horizontal: DOUBLE_HORIZONTAL_DIV, Vertical: DOUBLE_VERTICAL_DIV,
+		TopLeft: '╔', TopMid: '╦', TopRight: '╗',
+		MidLeft: '╠', MidMid: DOUBLE_CROSS_DIV, MidRight: '╣',
+		BottomLeft: '╚', BottomMid: '╩', BottomRight: '╝',
+		Outer: true,
+	}
+
+	ROUNDED_STYLE = Style{
+		Name:       "rounded",
+		Horizontal: SINGLE_HORIZONTAL_DIV, Vertical: SINGLE_VERTICAL_DIV,
+		TopLeft: '╭', TopMid: '┬', TopRight: '╮',
+		MidLeft: '├', MidMid: SINGLE_CROSS_DIV, MidRight: '┤',
+		BottomLeft: '╰', BottomMid: '┴', BottomRight: '╯',
+		Outer: true,
+	}
+
+	HEAVY_STYLE = Style{
+		Name:       "heavy",
+		Horizontal: '━', Vertical: '┃',
+		TopLeft: '┏', TopMid: '┳', TopRight: '┓',
+		MidLeft: '┣', MidMid: '╋', MidRight: '┫',
+		BottomLeft: '┗', BottomMid: '┻', BottomRight: '┛',
+		Outer: true,
+	}
+)
+
+/**
+ * Gets a border style by its name
+ *
+ * @param name Style name such as "none", "ascii", "single", "double", "rounded" or "heavy"
+ *
+ * @return The style, or an error if the style is unknown
+ */
+func StyleFor(name string) (Style, error) {
+	for _, style := range []Style{NONE_STYLE, ASCII_STYLE, SINGLE_STYLE, DOUBLE_STYLE, ROUNDED_STYLE, HEAVY_STYLE} {
+		if style.Name == strings.ToLower(name) {
+			return style, nil
+		}
+	}
+	return Style{}, fmt.Errorf("unknown table style: '%s'", name)
+}
+
+/**
+ * Gets the style the table should be drawn with
+ *
+ * @note When no style is set the inner grid is drawn with the
+ *       legacy VerticalDiv, HorizontalDiv and CrossDiv values.
+ *
+ * @return Style to draw the table with
+ */
+func (t *Table) style() Style {
+	if t.Style.Name != "" {
+		return t.Style
+	}
+
+	if t.HorizontalDiv == 0 {
+		t.HorizontalDiv = SINGLE_HORIZONTAL_DIV
+	}
+	if t.VerticalDiv == 0 {
+		t.VerticalDiv = SINGLE_VERTICAL_DIV
+	}
+	if t.CrossDiv == 0 {
+		t.CrossDiv = SINGLE_CROSS_DIV
+	}
+
+	return Style{
+		Horizontal: t.HorizontalDiv,
+		Vertical:   t.VerticalDiv,
+		TopMid:     t.HorizontalDiv,
+		MidMid:     t.CrossDiv,
+	}
+}
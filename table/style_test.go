@@ -0,0 +1,117 @@
+package table
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update the golden files in testdata")
+
+/**
+ * Compares a value to the golden file, or updates it with -update
+ *
+ * @param t Test doing the comparison
+ * @param name Name of the golden file in testdata
+ * @param actual Value to compare
+ */
+func checkGolden(t *testing.T, name string, actual string) {
+	t.Helper()
+
+	path := filepath.Join("testdata", name+".golden")
+	if *update {
+		if err := os.WriteFile(path, []byte(actual), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	expected, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actual != string(expected) {
+		t.Errorf("%s doesn't match the golden file, rerun with -update if the change is intended\n got:\n%s\nexpected:\n%s",
+			path, actual, expected)
+	}
+}
+
+/**
+ * Renders a table without colors
+ *
+ * @param t Table to render
+ *
+ * @return The TEXT format of the table
+ */
+func plain(t *Table) string {
+	colors := ColorsEnabled
+	ColorsEnabled = false
+	defer func() { ColorsEnabled = colors }()
+	return t.String()
+}
+
+/**
+ * Creates a small table of scores
+ *
+ * @return The table
+ */
+func scoresTable() *Table {
+	t := new(Table)
+	t.CreateColumn("Player", LEFT, 0)
+	t.CreateColumn("Points", RIGHT, 0)
+	t.AddEntry(map[string]any{"Player": "Ann", "Points": 120})
+	t.AddEntry(map[string]any{"Player": "Bob", "Points": 75})
+	return t
+}
+
+func TestNamedStyles(t *testing.T) {
+	for _, name := range []string{"none", "ascii", "single", "double", "rounded", "heavy"} {
+		t.Run(name, func(t *testing.T) {
+			style, err := StyleFor(strings.ToUpper(name))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			tbl := scoresTable()
+			tbl.Style = style
+			tbl.Title = "Scores"
+			tbl.AddFooter(map[string]any{"Player": "Total", "Points": 195})
+			checkGolden(t, "style_"+name, plain(tbl))
+		})
+	}
+
+	if _, err := StyleFor("dotted"); err == nil {
+		t.Error("expected an error for an unknown style")
+	}
+}
+
+func TestDefaultStyle(t *testing.T) {
+	checkGolden(t, "style_default", plain(scoresTable()))
+}
+
+func TestTitleWiderThanTable(t *testing.T) {
+	tbl := scoresTable()
+	tbl.Style = SINGLE_STYLE
+	tbl.Title = "Points of every player this game"
+
+	out := plain(tbl)
+	checkGolden(t, "title_wide", out)
+
+	// Every line is as wide as the title with its padding and borders
+	lines := strings.Split(strings.TrimSuffix(out, "\n\r"), "\n\r")
+	for _, line := range lines {
+		if width := textWidth(line); width != textWidth(tbl.Title)+(PADDING*2)+2 {
+			t.Errorf("line is %d wide: %q", width, line)
+		}
+	}
+}
+
+func TestFootersAndRowSeparators(t *testing.T) {
+	tbl := scoresTable()
+	tbl.Style = ASCII_STYLE
+	tbl.RowSeparators = true
+	tbl.AddFooter(map[string]any{"Player": "Total", "Points": 195})
+	tbl.AddFooter(map[string]any{"Player": "Average", "Points": 97.5})
+	checkGolden(t, "footers", plain(tbl))
+}
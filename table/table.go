@@ -1,8 +1,9 @@
 package table
 
 import (
-	"bytes"
 	"fmt"
+	"strings"
+	"unicode/utf8"
 )
 
 const PADDING = 1
@@ -14,8 +15,26 @@ const (
 	LEFT Alignment = iota
 	CENTER
 	RIGHT
+	AUTO // RIGHT if every entry is a number, otherwise LEFT
 )
 
+type Overflow int
+
+const (
+	TRUNCATE Overflow = iota // Cuts the value off at the max width
+	ELLIPSIS                 // Cuts the value off and ends it with '…'
+	WRAP                     // Continues the value on the following lines
+)
+
+/**
+ * Converts a cell value to the string that is printed
+ *
+ * @param val The value supplied in the row
+ *
+ * @return The value to print
+ */
+type Formatter func(val any) string
+
 const (
 	SINGLE_HORIZONTAL_DIV rune = '\u2500' // ─
 	SINGLE_VERTICAL_DIV   rune = '\u2502' // │
@@ -33,14 +52,27 @@ type Table struct {
 	HorizontalDiv rune
 	CrossDiv      rune
 	numEntries    int
+	numFooters    int
+
+	Style         Style  // Border style, if unset only the inner grid is drawn
+	Title         string // Optional line printed above the headers
+	RowSeparators bool   // True to draw a divider between every row
+
+	HeaderHighlight Highlight
+	rowHighlights   map[int]Highlight
+	Colors          ColorMode // When highlights are printed, AUTO_COLORS by default
 }
 
 type column struct {
-	header       string
-	alignment    Alignment
-	missingVal   rune
-	entries      map[int]string
-	maxEntrySize int
+	header     string
+	alignment  Alignment
+	missingVal rune
+	entries    map[int]any
+	footers    map[int]any
+	formatter  Formatter
+	maxWidth   int // 0 for no max width
+	overflow   Overflow
+	highlight  Highlight
 }
 
 /**
@@ -65,11 +97,11 @@ func (t *Table) CreateColumn(name string, alignment Alignment, missingVal rune)
 	}
 
 	col := &column{
-		header:       name,
-		alignment:    alignment,
-		missingVal:   missingVal,
-		entries:      make(map[int]string),
-		maxEntrySize: len(name),
+		header:     name,
+		alignment:  alignment,
+		missingVal: missingVal,
+		entries:    make(map[int]any),
+		footers:    make(map[int]any),
 	}
 
 	t.columns[col.header] = col
@@ -89,17 +121,39 @@ func (t *Table) CreateColumn(name string, alignment Alignment, missingVal rune)
  */
 func (t *Table) AddEntry(row map[string]any) error {
 	t.numEntries++
+	return t.addRow(row, t.numEntries, func(col *column) map[int]any { return col.entries })
+}
+
+/**
+ * Adds a footer row to the table such as a totals row.
+ *
+ * @note Footers are printed below all entries, separated by a divider
+ *
+ * @param row Contents of the row. Keys = Header Name, Values = Column Value
+ *
+ * @return An error is returned if one or more Keys refer to unspecified columns
+ */
+func (t *Table) AddFooter(row map[string]any) error {
+	t.numFooters++
+	return t.addRow(row, t.numFooters, func(col *column) map[int]any { return col.footers })
+}
 
+/**
+ * Stores the values of a row into the columns
+ *
+ * @param row Contents of the row. Keys = Header Name, Values = Column Value
+ * @param rowNum Row number the values are stored under
+ * @param cells Gets the cells of a column the values are stored in
+ *
+ * @return An error is returned if one or more Keys refer to unspecified columns
+ */
+func (t *Table) addRow(row map[string]any, rowNum int, cells func(*column) map[int]any) error {
 	var err error
 	var missingHdrs []string
 
 	for hdr, val := range row {
 		if col, has := t.columns[hdr]; has {
-			valStr := fmt.Sprint(val)
-			col.entries[t.numEntries] = valStr
-			if newMaxLen := len(valStr); col.maxEntrySize < newMaxLen {
-				col.maxEntrySize = newMaxLen
-			}
+			cells(col)[rowNum] = val
 		} else {
 			missingHdrs = append(missingHdrs, hdr)
 		}
@@ -110,59 +164,208 @@ func (t *Table) AddEntry(row map[string]any) error {
 	return err
 }
 
+/**
+ * Sets the function used to convert the column's values to strings
+ *
+ * @param name Header name of the column
+ * @param formatter Converts the values, if nil fmt.Sprint is used
+ *
+ * @return An error if no column exists with the header name
+ */
+func (t *Table) SetFormatter(name string, formatter Formatter) error {
+	col, has := t.columns[name]
+	if !has {
+		return fmt.Errorf("no column with header name exists: '%s'", name)
+	}
+	col.formatter = formatter
+	return nil
+}
+
+/**
+ * Limits how wide a column is printed
+ *
+ * @param name Header name of the column
+ * @param width Max number of characters in the column, 0 for no max
+ * @param overflow How values longer than the width are handled
+ *
+ * @return An error if no column exists with the header name or the width is negative
+ */
+func (t *Table) SetMaxWidth(name string, width int, overflow Overflow) error {
+	col, has := t.columns[name]
+	if !has {
+		return fmt.Errorf("no column with header name exists: '%s'", name)
+	} else if width < 0 {
+		return fmt.Errorf("invalid max width: %d", width)
+	}
+	col.maxWidth = width
+	col.overflow = overflow
+	return nil
+}
+
 func (t *Table) String() string {
-	buf := bytes.NewBufferString("")
+	return t.Render(TEXT)
+}
+
+/**
+ * Gets the value of a cell to be printed
+ *
+ * @param row Row number of the entry (1 based)
+ * @param col Column the entry is located in
+ *
+ * @return The entry value, or the column's missing value if not supplied
+ */
+func (t *Table) cell(row int, col *column) string {
+	val, has := col.entries[row]
+	if !has {
+		if col.missingVal == EMPTY_MISSING_VAL {
+			return ""
+		}
+		return string(col.missingVal)
+	}
+	return col.format(val)
+}
 
-	if t.HorizontalDiv == 0 {
-		t.HorizontalDiv = SINGLE_HORIZONTAL_DIV
+/**
+ * Gets the value of a footer cell to be printed
+ *
+ * @param row Footer row number (1 based)
+ * @param col Column the footer is located in
+ *
+ * @return The footer value, or an empty string if not supplied
+ */
+func (t *Table) footer(row int, col *column) string {
+	val, has := col.footers[row]
+	if !has {
+		return ""
 	}
-	if t.VerticalDiv == 0 {
-		t.VerticalDiv = SINGLE_VERTICAL_DIV
+	return col.format(val)
+}
+
+/**
+ * Converts a value to a string with the column's formatter
+ *
+ * @param val Value to convert
+ *
+ * @return The formatted value
+ */
+func (col *column) format(val any) string {
+	if cell, isCell := val.(Cell); isCell {
+		val = cell.Value
 	}
-	if t.CrossDiv == 0 {
-		t.CrossDiv = SINGLE_CROSS_DIV
+	if col.formatter != nil {
+		return col.formatter(val)
 	}
+	return fmt.Sprint(val)
+}
 
-	for i := -1; i <= t.numEntries; i++ {
-		for pos, colName := range t.columnOrder {
-			col := t.columns[colName]
-			switch i {
-			case -1:
-				header := formatColEntry(col.header, col.alignment, col.maxEntrySize)
-				buf.WriteString(pad(header, PADDING))
-			case 0:
-				var hdrDiv string
-				for i := 0; i < col.maxEntrySize+(PADDING*2); i++ {
-					hdrDiv += string(t.HorizontalDiv)
-				}
-				buf.WriteString(string(hdrDiv))
-			default:
-				entry, has := col.entries[i]
-				if !has {
-					if col.missingVal == EMPTY_MISSING_VAL {
-						entry = ""
-					} else {
-						entry = string(col.missingVal)
-					}
-				}
+/**
+ * Gets how the column's values should be aligned
+ *
+ * @return The alignment with AUTO resolved to LEFT or RIGHT
+ */
+func (col *column) align() Alignment {
+	if col.alignment != AUTO {
+		return col.alignment
+	}
+	for _, val := range col.entries {
+		if _, isNum := number(val); !isNum {
+			return LEFT
+		}
+	}
+	return RIGHT
+}
 
-				row := formatColEntry(entry, col.alignment, col.maxEntrySize)
-				buf.WriteString(pad(row, PADDING))
-			}
+/**
+ * Gets the width the column will be printed at
+ *
+ * @param t Table the column belongs to
+ *
+ * @return The width of the largest value, header, or the column's max width
+ */
+func (col *column) width(t *Table) int {
+	width := textWidth(col.header)
+	for i := 1; i <= t.numEntries; i++ {
+		width = max(width, widthOfLines(t.cell(i, col)))
+	}
+	for i := 1; i <= t.numFooters; i++ {
+		width = max(width, widthOfLines(t.footer(i, col)))
+	}
+	if col.maxWidth > 0 {
+		width = min(width, col.maxWidth)
+	}
+	return width
+}
+
+/**
+ * Splits a value into the lines printed for a column
+ *
+ * @param val Value to split
+ * @param width Width the column is printed at, 0 for no limit
+ *
+ * @return The lines that fit in the width
+ */
+func (col *column) lines(val string, width int) []string {
+	lines := strings.Split(val, "\n")
+	if width <= 0 {
+		return lines
+	}
+
+	fitted := make([]string, 0, len(lines))
+	for _, line := range lines {
+		runes := []rune(line)
+		if len(runes) <= width {
+			fitted = append(fitted, line)
+			continue
+		}
 
-			if pos+1 != len(t.columnOrder) {
-				if i == 0 {
-					buf.WriteString(string(t.CrossDiv))
-				} else {
-					buf.WriteString(string(t.VerticalDiv))
+		switch col.overflow {
+		case WRAP:
+			for len(runes) > width {
+				// Prefer breaking on the last space that fits
+				cut := width
+				for i := width; i > 0; i-- {
+					if runes[i] == ' ' {
+						cut = i
+						break
+					}
 				}
-			} else {
-				buf.WriteString("\n\r")
+				fitted = append(fitted, strings.TrimRight(string(runes[:cut]), " "))
+				runes = []rune(strings.TrimLeft(string(runes[cut:]), " "))
 			}
+			fitted = append(fitted, string(runes))
+		case ELLIPSIS:
+			fitted = append(fitted, string(runes[:width-1])+"…")
+		default:
+			fitted = append(fitted, string(runes[:width]))
 		}
 	}
+	return fitted
+}
 
-	return buf.String()
+/**
+ * Gets the number of characters printed for a value
+ *
+ * @param val Value to measure
+ *
+ * @return Printed width of the value
+ */
+func textWidth(val string) int {
+	return utf8.RuneCountInString(stripAnsi(val))
+}
+
+/**
+ * Gets the printed width of the widest line in a value
+ *
+ * @param val Value to measure
+ *
+ * @return Printed width of the widest line
+ */
+func widthOfLines(val string) int {
+	widest := 0
+	for _, line := range strings.Split(val, "\n") {
+		widest = max(widest, textWidth(line))
+	}
+	return widest
 }
 
 /**
@@ -179,19 +382,16 @@ func (t *Table) String() string {
  * @return The aligned value
  */
 func formatColEntry(val string, alignment Alignment, width int) string {
-	var valFmt string
+	space := max(width-textWidth(val), 0)
 	switch alignment {
 	case RIGHT:
-		valFmt = fmt.Sprintf("%%%ds%%s", width)
+		return strings.Repeat(" ", space) + val
 	case CENTER:
-		valWidth := len(val)
-		left := (width - valWidth) / 2
-		right := width - valWidth - left
-		valFmt = fmt.Sprintf("%%%ds%%%ds", valWidth+left, right)
+		left := space / 2
+		return strings.Repeat(" ", left) + val + strings.Repeat(" ", space-left)
 	default:
-		valFmt = fmt.Sprintf("%%-%ds%%s", width)
+		return val + strings.Repeat(" ", space)
 	}
-	return fmt.Sprintf(valFmt, val, "")
 }
 
 /**
@@ -0,0 +1,86 @@
+package table
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestOverflow(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		overflow Overflow
+		width    int
+		val      string
+		want     []string
+	}{
+		{"truncate", TRUNCATE, 4, "abcdefg", []string{"abcd"}},
+		{"truncate fits", TRUNCATE, 7, "abcdefg", []string{"abcdefg"}},
+		{"ellipsis", ELLIPSIS, 4, "abcdefg", []string{"abc…"}},
+		{"ellipsis width 1", ELLIPSIS, 1, "abcdefg", []string{"…"}},
+		{"ellipsis per line", ELLIPSIS, 3, "abcd\nef", []string{"ab…", "ef"}},
+		{"wrap on spaces", WRAP, 7, "bank the points", []string{"bank", "the", "points"}},
+		{"wrap long word", WRAP, 3, "abcdefgh", []string{"abc", "def", "gh"}},
+		{"wrap long word after space", WRAP, 4, "a bcdefghij", []string{"a", "bcde", "fghi", "j"}},
+		{"wrap width 1", WRAP, 1, "ab c", []string{"a", "b", "c"}},
+		{"no width", WRAP, 0, "abcdefg", []string{"abcdefg"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			col := &column{overflow: tc.overflow}
+			if got := col.lines(tc.val, tc.width); !slices.Equal(got, tc.want) {
+				t.Errorf("got %q, expected %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestMaxWidth(t *testing.T) {
+	tbl := new(Table)
+	tbl.CreateColumn("Name", LEFT, 0)
+	tbl.CreateColumn("Note", LEFT, 0)
+	tbl.AddEntry(map[string]any{"Name": "Alexander", "Note": "banks early"})
+
+	if err := tbl.SetMaxWidth("Name", 5, ELLIPSIS); err != nil {
+		t.Fatal(err)
+	}
+	if err := tbl.SetMaxWidth("Note", 5, WRAP); err != nil {
+		t.Fatal(err)
+	}
+
+	want := " Name  │ Note  \n\r" +
+		"───────┼───────\n\r" +
+		" Alex… │ banks \n\r" +
+		"       │ early \n\r"
+	if got := plain(tbl); got != want {
+		t.Errorf("got:\n%s\nexpected:\n%s", got, want)
+	}
+
+	if err := tbl.SetMaxWidth("Name", -1, WRAP); err == nil {
+		t.Error("expected an error for a negative width")
+	}
+	if err := tbl.SetMaxWidth("Score", 5, WRAP); err == nil {
+		t.Error("expected an error for an unknown column")
+	}
+}
+
+func TestAutoAlignment(t *testing.T) {
+	tbl := new(Table)
+	tbl.CreateColumn("Numbers", AUTO, 0)
+	tbl.CreateColumn("Mixed", AUTO, 0)
+	tbl.CreateColumn("Missing", AUTO, '-')
+	tbl.AddEntry(map[string]any{"Numbers": 1, "Mixed": 2, "Missing": uint(3)})
+	tbl.AddEntry(map[string]any{"Numbers": 2.5, "Mixed": "two"})
+
+	for name, want := range map[string]Alignment{"Numbers": RIGHT, "Mixed": LEFT, "Missing": RIGHT} {
+		if got := tbl.columns[name].align(); got != want {
+			t.Errorf("%s: got %v, expected %v", name, got, want)
+		}
+	}
+
+	want := " Numbers │ Mixed │ Missing \n\r" +
+		"─────────┼───────┼─────────\n\r" +
+		"       1 │ 2     │       3 \n\r" +
+		"     2.5 │ two   │       - \n\r"
+	if got := plain(tbl); got != want {
+		t.Errorf("got:\n%s\nexpected:\n%s", got, want)
+	}
+}
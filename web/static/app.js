@@ -0,0 +1,212 @@
+"use strict";
+
+// Pip positions on a 3x3 grid for each face, matching the terminal dice
+const PIPS = {
+  1: [[2, 2]],
+  2: [[1, 1], [3, 3]],
+  3: [[1, 1], [2, 2], [3, 3]],
+  4: [[1, 1], [1, 3], [3, 1], [3, 3]],
+  5: [[1, 1], [1, 3], [2, 2], [3, 1], [3, 3]],
+  6: [[1, 1], [1, 3], [2, 1], [2, 3], [3, 1], [3, 3]],
+};
+
+const $ = (id) => document.getElementById(id);
+
+let gameId = null;
+let state = null;
+let events = null;
+
+async function api(method, path, body) {
+  const res = await fetch("/api/games" + path, {
+    method,
+    headers: body ? { "Content-Type": "application/json" } : {},
+    body: body ? JSON.stringify(body) : undefined,
+  });
+  const data = await res.json();
+  if (!res.ok) {
+    throw new Error(data.error || res.statusText);
+  }
+  return data;
+}
+
+// Runs an action, showing any error it throws
+async function attempt(action) {
+  $("error").classList.add("hidden");
+  try {
+    await action();
+  } catch (err) {
+    $("error").textContent = err.message;
+    $("error").classList.remove("hidden");
+  }
+}
+
+function drawDie(el, value, seven) {
+  el.replaceChildren();
+  for (const [row, col] of PIPS[value] || []) {
+    const pip = document.createElement("span");
+    pip.className = "pip";
+    pip.style.gridArea = `${row} / ${col}`;
+    el.appendChild(pip);
+  }
+  el.classList.toggle("seven", seven);
+  el.classList.remove("rolling");
+  void el.offsetWidth; // Restart the animation
+  el.classList.add("rolling");
+}
+
+function render() {
+  if (!state) {
+    return;
+  }
+
+  $("lobby").classList.toggle("hidden", state.started);
+  $("setup").classList.toggle("hidden", state.started);
+  $("board").classList.toggle("hidden", !state.started);
+  $("standings-section").classList.toggle("hidden", state.players.length === 0);
+
+  let round = `Round ${state.currentRound + 1}`;
+  round += state.targetScore ? `, first to ${state.targetScore}` : ` of ${state.maxRounds}`;
+  $("round").textContent = state.started ? round : "";
+  $("pot-value").textContent = state.roundPoints;
+  $("roll-number").textContent = state.rollNumber ? `Roll ${state.rollNumber}` : "New round";
+  $("roll").disabled = state.over;
+
+  if (state.over) {
+    $("message").textContent = `${state.winners.join(" & ")} won!`;
+  }
+
+  const list = $("standings");
+  list.replaceChildren();
+  for (const player of state.players) {
+    const item = document.createElement("li");
+
+    const name = document.createElement("span");
+    name.className = "name";
+    name.textContent = player.name;
+    if (player.aiAgent) {
+      const ai = document.createElement("span");
+      ai.className = "ai";
+      ai.textContent = " AI";
+      name.appendChild(ai);
+    }
+
+    const points = document.createElement("span");
+    points.className = "points";
+    points.textContent = player.points;
+
+    item.append(name, points);
+
+    // Knocked out and forfeited players can't bank anymore
+    if (player.eliminated) {
+      const out = document.createElement("span");
+      out.className = "out";
+      out.textContent = player.forfeited ? "LEFT" : "OUT";
+      item.appendChild(out);
+    } else if (player.banked) {
+      const banked = document.createElement("span");
+      banked.className = "banked";
+      banked.textContent = "✔";
+      item.appendChild(banked);
+    } else if (state.started && !state.over && !player.aiAgent) {
+      const bank = document.createElement("button");
+      bank.className = "bank";
+      bank.textContent = "BANK";
+      bank.disabled = state.rollNumber === 0;
+      bank.onclick = () => attempt(async () => {
+        state = await api("POST", `/${gameId}/players/${encodeURIComponent(player.name)}/bank`);
+        render();
+      });
+      item.appendChild(bank);
+    }
+
+    list.appendChild(item);
+  }
+}
+
+async function refresh() {
+  state = await api("GET", `/${gameId}`);
+  render();
+}
+
+function join(id) {
+  gameId = String(id);
+  location.hash = gameId;
+  $("share-id").textContent = gameId;
+  $("share").classList.remove("hidden");
+
+  if (events) {
+    events.close();
+  }
+  events = new EventSource(`/api/games/${gameId}/events`);
+  events.addEventListener("state", (e) => {
+    state = JSON.parse(e.data);
+    render();
+  });
+  events.addEventListener("roll", (e) => {
+    const roll = JSON.parse(e.data);
+    const seven = roll.dice[0] + roll.dice[1] === 7;
+    drawDie($("die-0"), roll.dice[0], seven && roll.busted);
+    drawDie($("die-1"), roll.dice[1], seven && roll.busted);
+    $("message").textContent = roll.busted ? `Seven! The round is over.` : "";
+  });
+  events.addEventListener("bank", (e) => {
+    const bank = JSON.parse(e.data);
+    $("message").textContent = `${bank.player} banked ${bank.points}!`;
+  });
+  events.addEventListener("undo", (e) => {
+    const undo = JSON.parse(e.data);
+    const players = undo.banks.map((bank) => bank.player).join(", ");
+    $("message").textContent = `${undo.host} undid the banks of ${players}.`;
+  });
+  // Other devices may have changed the game, so fetch the latest state
+  for (const name of ["start", "roll", "bank", "undo", "seat", "round", "end"]) {
+    events.addEventListener(name, () => attempt(refresh));
+  }
+}
+
+$("new-game").onclick = () => attempt(async () => {
+  state = await api("POST", "");
+  join(state.id);
+  render();
+});
+
+$("join-game").onclick = () => attempt(async () => {
+  gameId = $("game-id").value.trim();
+  await refresh();
+  join(gameId);
+});
+
+$("player-ai").onchange = () => {
+  $("player-bank-at").classList.toggle("hidden", !$("player-ai").checked);
+};
+
+$("add-player").onclick = () => attempt(async () => {
+  const name = $("player-name").value.trim();
+  state = await api("POST", `/${gameId}/players`, {
+    name,
+    ai: $("player-ai").checked,
+    bankAt: Number($("player-bank-at").value),
+  });
+  $("player-name").value = "";
+  render();
+});
+
+$("start").onclick = () => attempt(async () => {
+  state = await api("POST", `/${gameId}/start`);
+  render();
+});
+
+$("roll").onclick = () => attempt(async () => {
+  const res = await api("POST", `/${gameId}/roll`);
+  state = res.state;
+  render();
+});
+
+// Rejoin the game in the URL after a reload
+if (location.hash.length > 1) {
+  attempt(async () => {
+    gameId = location.hash.slice(1);
+    await refresh();
+    join(gameId);
+  });
+}
@@ -0,0 +1,26 @@
+package web
+
+import (
+	"embed"
+	"io/fs"
+	"net/http"
+)
+
+//go:embed static
+var static embed.FS
+
+/**
+ * Creates the handler serving the single page web client.
+ *
+ * @note The page plays games through the server package's /api endpoints,
+ *       so it must be served by the same host.
+ *
+ * @return Handler serving the embedded files
+ */
+func Handler() http.Handler {
+	files, err := fs.Sub(static, "static")
+	if err != nil {
+		panic(err) // The embedded directory always exists
+	}
+	return http.FileServer(http.FS(files))
+}
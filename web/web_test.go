@@ -0,0 +1,32 @@
+package web
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestServesClient(t *testing.T) {
+	handler := Handler()
+
+	for path, expected := range map[string]string{
+		"/":           "<title>Bank</title>",
+		"/app.js":     "/api/games",
+		"/style.css":  ".die",
+		"/index.html": "", // Redirects to /
+	} {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest("GET", path, nil))
+
+		if expected == "" {
+			if rec.Code != http.StatusMovedPermanently {
+				t.Errorf("%s: expected a redirect, got %d", path, rec.Code)
+			}
+			continue
+		}
+		if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), expected) {
+			t.Errorf("%s: got %d, expected it to contain %q", path, rec.Code, expected)
+		}
+	}
+}